@@ -0,0 +1,63 @@
+package connections
+
+import (
+	"context"
+	"errors"
+	"github.com/gflydev/cache"
+	"github.com/gflydev/core/log"
+	"github.com/redis/go-redis/v9"
+	"time"
+)
+
+// ====================================================================
+// ============================ Cache Driver ==========================
+// ====================================================================
+
+// Cache create a cache driver on the shared connection pool CacheRedis. It behaves as
+// `gflydev/cache/redis`, whose connection is private and can be neither reused nor closed.
+//
+//	cache.Register(connections.Cache())
+func Cache() cache.ICache {
+	return &cacheDriver{}
+}
+
+// cacheDriver a Redis cache driver using CacheRedis.
+type cacheDriver struct{}
+
+// Set implement cache.ICache.
+func (d *cacheDriver) Set(key string, value any, expiration time.Duration) error {
+	if err := CacheRedis().Set(context.Background(), cache.Key(key), value, expiration).Err(); err != nil {
+		log.Errorf("Error while writing Redis cache %q", err)
+
+		return err
+	}
+
+	return nil
+}
+
+// Get implement cache.ICache.
+func (d *cacheDriver) Get(key string) (any, error) {
+	value, err := CacheRedis().Get(context.Background(), cache.Key(key)).Result()
+	if errors.Is(err, redis.Nil) {
+		// A missing key is a normal cache miss
+		return nil, err
+	}
+	if err != nil {
+		log.Warnf("Error while reading key `%v`: %v", key, err)
+
+		return nil, err
+	}
+
+	return value, nil
+}
+
+// Del implement cache.ICache.
+func (d *cacheDriver) Del(key string) error {
+	if err := CacheRedis().Del(context.Background(), cache.Key(key)).Err(); err != nil {
+		log.Warnf("Error while deleting key `%v`", key)
+
+		return err
+	}
+
+	return nil
+}
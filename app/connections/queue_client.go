@@ -0,0 +1,50 @@
+package connections
+
+import (
+	"github.com/gflydev/core/utils"
+	"github.com/hibiken/asynq"
+	"sync"
+)
+
+// ====================================================================
+// ============================ Queue Client ==========================
+// ====================================================================
+
+var (
+	queueClient     *asynq.Client
+	queueClientLock sync.Mutex
+)
+
+// Queue get the asynq client pushing tasks, opened on first use. Its settings follow `gflydev/console`:
+// env REDIS_HOST, REDIS_PORT, REDIS_PASSWORD, REDIS_QUEUE_NUMBER.
+//
+//	info, err := connections.Queue().Enqueue(asynq.NewTask(name, payload))
+func Queue() *asynq.Client {
+	queueClientLock.Lock()
+	defer queueClientLock.Unlock()
+
+	if queueClient == nil {
+		queueClient = asynq.NewClient(asynq.RedisClientOpt{
+			Addr:     redisAddr(),
+			Password: utils.Getenv("REDIS_PASSWORD", ""),
+			DB:       utils.Getenv("REDIS_QUEUE_NUMBER", 0),
+		})
+	}
+
+	return queueClient
+}
+
+// CloseQueue close the connection pool of the asynq client. Do nothing if the client was not opened.
+func CloseQueue() error {
+	queueClientLock.Lock()
+	defer queueClientLock.Unlock()
+
+	if queueClient == nil {
+		return nil
+	}
+
+	err := queueClient.Close()
+	queueClient = nil
+
+	return err
+}
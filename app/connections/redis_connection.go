@@ -0,0 +1,110 @@
+package connections
+
+import (
+	"fmt"
+	"github.com/gflydev/core/utils"
+	"github.com/redis/go-redis/v9"
+	"sync"
+	"time"
+)
+
+// ====================================================================
+// ========================= Redis Connections ========================
+// ====================================================================
+
+var (
+	cachePool = &redisPool{
+		options: func() *redis.Options {
+			return redisOptions(utils.Getenv("REDIS_DEFAULT_DB", 0))
+		},
+	}
+
+	sessionPool = &redisPool{
+		options: func() *redis.Options {
+			options := redisOptions(utils.Getenv("REDIS_SESSION_DB", 0))
+			options.PoolSize = 8
+			options.ConnMaxIdleTime = 30 * time.Second
+
+			return options
+		},
+	}
+)
+
+// CacheRedis get the Redis connection pool of the cache database, opened on first use.
+// Its settings follow `gflydev/cache/redis`: env REDIS_HOST, REDIS_PORT, REDIS_PASSWORD, REDIS_DEFAULT_DB.
+// The cache driver and the rate limiter share it.
+func CacheRedis() *redis.Client {
+	return cachePool.get()
+}
+
+// CloseCacheRedis close the Redis connection pool of the cache database, waiting for running commands.
+// Do nothing if the pool was not opened.
+func CloseCacheRedis() error {
+	return cachePool.close()
+}
+
+// SessionRedis get the Redis connection pool of the session database, opened on first use.
+// Its settings follow `gflydev/session/redis`: env REDIS_HOST, REDIS_PORT, REDIS_PASSWORD, REDIS_SESSION_DB.
+func SessionRedis() *redis.Client {
+	return sessionPool.get()
+}
+
+// CloseSessionRedis close the Redis connection pool of the session database, waiting for running commands.
+// Do nothing if the pool was not opened.
+func CloseSessionRedis() error {
+	return sessionPool.close()
+}
+
+// redisPool a Redis connection pool opened on first use.
+type redisPool struct {
+	options func() *redis.Options
+	client  *redis.Client
+	lock    sync.Mutex
+}
+
+// get the client, opening it if needed.
+func (p *redisPool) get() *redis.Client {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if p.client == nil {
+		p.client = redis.NewClient(p.options())
+	}
+
+	return p.client
+}
+
+// close the client if it was opened. The next get opens a new one.
+func (p *redisPool) close() error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if p.client == nil {
+		return nil
+	}
+
+	err := p.client.Close()
+	p.client = nil
+
+	return err
+}
+
+// redisOptions options to connect to given database of the Redis server.
+func redisOptions(db int) *redis.Options {
+	return &redis.Options{
+		Addr:     redisAddr(),
+		Password: utils.Getenv("REDIS_PASSWORD", ""),
+		DB:       db,
+		// Commands give up when their context is done, like readiness checks on timeout
+		ContextTimeoutEnabled: true,
+	}
+}
+
+// redisAddr address of the Redis server given by env REDIS_HOST and REDIS_PORT.
+func redisAddr() string {
+	return fmt.Sprintf(
+		"%s:%d",
+		utils.Getenv("REDIS_HOST", "localhost"),
+		utils.Getenv("REDIS_PORT", 6379),
+	)
+}
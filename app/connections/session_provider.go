@@ -0,0 +1,92 @@
+package connections
+
+import (
+	"context"
+	stdErrors "errors"
+	"github.com/gflydev/core/utils"
+	"github.com/gflydev/session"
+	"github.com/redis/go-redis/v9"
+	"time"
+)
+
+// ====================================================================
+// ========================= Session Provider =========================
+// ====================================================================
+
+// Session create a session provider on the connection pool SessionRedis. It behaves as
+// `gflydev/session/redis`, whose connection is private and can be neither reused nor closed.
+// Session keys are prefixed by env SESSION_KEY.
+//
+//	session.Register(connections.Session())
+func Session() session.Provider {
+	return &sessionProvider{
+		keyPrefix: utils.Getenv("SESSION_KEY", "gfly_session"),
+	}
+}
+
+// sessionProvider a Redis session provider using SessionRedis.
+type sessionProvider struct {
+	keyPrefix string
+}
+
+// Get implement session.Provider. An unknown session has no data.
+func (p *sessionProvider) Get(id []byte) ([]byte, error) {
+	data, err := SessionRedis().Get(context.Background(), p.key(id)).Bytes()
+	if err != nil && !stdErrors.Is(err, redis.Nil) {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// Save implement session.Provider.
+func (p *sessionProvider) Save(id, data []byte, expiration time.Duration) error {
+	return SessionRedis().Set(context.Background(), p.key(id), data, expiration).Err()
+}
+
+// Destroy implement session.Provider.
+func (p *sessionProvider) Destroy(id []byte) error {
+	return SessionRedis().Del(context.Background(), p.key(id)).Err()
+}
+
+// Regenerate implement session.Provider. Move the session data to `newID` if it exists.
+func (p *sessionProvider) Regenerate(id, newID []byte, expiration time.Duration) error {
+	client := SessionRedis()
+	key, newKey := p.key(id), p.key(newID)
+
+	exists, err := client.Exists(context.Background(), key).Result()
+	if err != nil || exists == 0 {
+		return err
+	}
+
+	if err = client.Rename(context.Background(), key, newKey).Err(); err != nil {
+		return err
+	}
+
+	return client.Expire(context.Background(), newKey, expiration).Err()
+}
+
+// Count implement session.Provider.
+func (p *sessionProvider) Count() int {
+	keys, err := SessionRedis().Keys(context.Background(), p.key([]byte("*"))).Result()
+	if err != nil {
+		return 0
+	}
+
+	return len(keys)
+}
+
+// NeedGC implement session.Provider. Redis expires sessions itself.
+func (p *sessionProvider) NeedGC() bool {
+	return false
+}
+
+// GC implement session.Provider.
+func (p *sessionProvider) GC() error {
+	return nil
+}
+
+// key Redis key of given session ID.
+func (p *sessionProvider) key(id []byte) string {
+	return p.keyPrefix + ":" + string(id)
+}
@@ -1,50 +1,152 @@
 package main
 
 import (
-	_ "gfly/app/console/commands"  // Autoload commands into pool.
-	_ "gfly/app/console/queues"    // Autoload tasks into queue.
-	_ "gfly/app/console/schedules" // Autoload jobs into schedule.
+	"fmt"
+	"gfly/app/connections"
+	_ "gfly/app/console/commands" // Autoload commands into pool.
+	"gfly/app/console/generate"
+	"gfly/app/console/migrate"
+	"gfly/app/console/queues" // Autoload tasks into queue.
+	"gfly/app/console/route"
+	"gfly/app/console/schedules" // Autoload jobs into schedule.
+	"gfly/app/console/seed"
+	"gfly/app/domain/repository"
+	"gfly/app/lifecycle"
+	"gfly/app/metrics"
+	"gfly/app/tracing"
+	_ "gfly/database/seeders" // Autoload seeders into pool.
 	"github.com/gflydev/cache"
-	cacheRedis "github.com/gflydev/cache/redis"
 	"github.com/gflydev/console"
+	"github.com/gflydev/core/log"
 	mb "github.com/gflydev/db"
-	dbPSQL "github.com/gflydev/db/psql"
 	notificationMail "github.com/gflydev/notification/mail"
 	_ "github.com/joho/godotenv/autoload" // load .env file automatically
 	"os"
+	"slices"
 )
 
 func main() {
+	// Start tracing
+	tracing.Start()
+
+	// Close resources on exit
+	manager := lifecycle.New()
+
 	// Register mail notification
 	notificationMail.AutoRegister()
 
-	// Register Redis cache
-	cache.Register(cacheRedis.New())
-
-	// Register DB driver & Load Model builder
-	mb.Register(dbPSQL.New())
-	mb.Load()
+	// Register Redis cache, tracing its commands
+	tracing.Redis(connections.CacheRedis())
+	cache.Register(metrics.Cache(connections.Cache()))
 
 	args := os.Args[1:] // Skip application name
 
+	// Register DB driver & Load Model builder, only for modes using the database
+	if needsDatabase(args) {
+		mb.Register(repository.Driver(tracing.Postgres()))
+		mb.Load()
+	}
+
+	err := run(manager, args)
+
+	// Close resources before exit, log.Fatal would skip them
+	manager.Shutdown()
+
+	if err != nil {
+		log.Errorf("%s failed: %v", args[0], err)
+		os.Exit(1)
+	}
+}
+
+// needsDatabase tell whether a mode reads or writes the database. Generators only need it with --from-db.
+func needsDatabase(args []string) bool {
+	if len(args) == 0 {
+		return false
+	}
+
+	switch {
+	case args[0] == route.ModeList:
+		return false
+	case slices.Contains(generate.Modes, args[0]):
+		return generate.NeedsDatabase(args[1:])
+	default:
+		return true
+	}
+}
+
+// run run the mode of `args`, adding its shutdown phases to `manager`.
+func run(manager *lifecycle.Manager, args []string) error {
 	switch {
 	case len(args) > 0 && args[0] == "schedule:run":
 		/*---------------------------------------
 						Scheduler
 		----------------------------------------*/
-		// Start scheduler
-		console.StartScheduler()
+		// Serve metrics at admin port & start scheduler
+		metrics.ListenAndServe()
+		scheduler, err := schedules.StartScheduler()
+		if err != nil {
+			return fmt.Errorf("could not start scheduler: %w", err)
+		}
+
+		// Stop cron ticks and wait for running jobs on SIGTERM
+		manager.OnShutdown("scheduler", scheduler.Shutdown)
+		manager.CloseResources()
+		manager.Wait()
 	case len(args) > 0 && args[0] == "queue:run":
 		/*---------------------------------------
 						QueueJob
 		----------------------------------------*/
-		// Start queue worker
-		console.StartQueueWorker()
+		// Serve metrics at admin port & start queue worker
+		metrics.ListenAndServe()
+		worker, err := queues.StartWorker(lifecycle.Timeout())
+		if err != nil {
+			return fmt.Errorf("could not start queue worker: %w", err)
+		}
+
+		// Stop pulling tasks and wait for running ones on SIGTERM
+		manager.OnShutdown("queue worker", worker.Shutdown)
+		manager.CloseResources()
+		manager.Wait()
+	case len(args) > 0 && slices.Contains(migrate.Modes, args[0]):
+		/*---------------------------------------
+						Migration
+		----------------------------------------*/
+		// Run migrations from embedded SQL files
+		manager.CloseResources()
+
+		return migrate.Run(args)
+	case len(args) > 0 && args[0] == "db:seed":
+		/*---------------------------------------
+						Seeder
+		----------------------------------------*/
+		// Run all seeders or given ones with their dependencies
+		manager.CloseResources()
+
+		return seed.Run(args[1:]...)
+	case len(args) > 0 && slices.Contains(generate.Modes, args[0]):
+		/*---------------------------------------
+						Generator
+		----------------------------------------*/
+		// Write new files from templates
+		manager.CloseResources()
+
+		return generate.Run(args)
+	case len(args) > 0 && args[0] == route.ModeList:
+		/*---------------------------------------
+						Route list
+		----------------------------------------*/
+		// Print routes of web server
+		manager.CloseResources()
+
+		return route.Run(args)
 	case len(args) > 0 && args[0] == "cmd:run":
 		/*---------------------------------------
 						Command
 		----------------------------------------*/
 		// Run command
+		manager.CloseResources()
 		console.RunCommands(args[1:])
 	}
+
+	return nil
 }
@@ -2,9 +2,9 @@ package commands
 
 import (
 	"gfly/app/domain/models"
+	"gfly/app/domain/repository"
 	"github.com/gflydev/console"
 	"github.com/gflydev/core/log"
-	mb "github.com/gflydev/db"
 	"time"
 )
 
@@ -29,7 +29,7 @@ type DBCommand struct {
 
 // Handle Process command.
 func (c *DBCommand) Handle() {
-	user, err := mb.GetModelBy[models.User]("email", "admin@gfly.dev")
+	user, err := repository.GetModelBy[models.User]("email", "admin@gfly.dev")
 	if err != nil || user == nil {
 		log.Panic(err)
 	}
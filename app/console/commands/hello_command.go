@@ -1,7 +1,9 @@
 package commands
 
 import (
+	"context"
 	"gfly/app/console/queues"
+	"gfly/app/tracing"
 	"github.com/gflydev/console"
 	"github.com/gflydev/core/log"
 	"time"
@@ -29,7 +31,8 @@ type HelloCommand struct {
 // Handle Process command.
 func (c *HelloCommand) Handle() {
 	// Dispatch a task into Queue.
-	console.DispatchTask(queues.NewHelloTask("Hello"))
+	payload, name := queues.NewHelloTask("Hello")
+	tracing.DispatchTask(context.Background(), payload, name)
 
 	log.Infof("HellCommand :: Run at %s", time.Now().Format("2006-01-02 15:04:05"))
 }
@@ -4,6 +4,7 @@ import (
 	"gfly/app/notifications"
 	"github.com/gflydev/console"
 	"github.com/gflydev/core/log"
+	"github.com/gflydev/core/utils"
 	"github.com/gflydev/notification"
 	"time"
 )
@@ -31,7 +32,10 @@ type MailCommand struct {
 func (c *MailCommand) Handle() {
 	// ============== Send mail ==============
 	resetPassword := notifications.ResetPassword{
-		Email: "admin@gfly.dev",
+		Email:     "admin@gfly.dev",
+		Fullname:  "Admin",
+		Link:      utils.Getenv("APP_URL", "") + "/reset-password?token=test",
+		ExpiresAt: time.Now().Add(time.Hour),
 	}
 
 	if err := notification.Send(resetPassword); err != nil {
@@ -0,0 +1,77 @@
+package commands
+
+import (
+	"fmt"
+	"gfly/app/domain/models"
+	"gfly/app/domain/repository"
+	"github.com/gflydev/console"
+	"github.com/gflydev/core/errors"
+	"github.com/gflydev/core/log"
+	"github.com/gflydev/core/utils"
+	"strconv"
+	"time"
+)
+
+// ---------------------------------------------------------------
+//                      Register command.
+// ./artisan cmd:run purge-trashed
+// ./artisan cmd:run purge-trashed --days=7
+// ---------------------------------------------------------------
+
+// Auto-register command.
+func init() {
+	console.RegisterCommand(&PurgeTrashedCommand{}, "purge-trashed")
+}
+
+// ---------------------------------------------------------------
+//                      PurgeTrashedCommand struct.
+// ---------------------------------------------------------------
+
+// trashedPurgers permanently delete soft deleted rows of each model before a given time.
+var trashedPurgers = map[string]func(before time.Time) (int, error){
+	models.TableUser:    repository.PurgeTrashed[models.User],
+	models.TableAddress: repository.PurgeTrashed[models.Address],
+}
+
+// PurgeTrashedCommand struct for purging soft deleted rows which are older than retention period.
+type PurgeTrashedCommand struct {
+	console.Command
+	days int
+}
+
+// Validate Verify command parameters. Retention days come from `--days` or env SOFT_DELETE_RETENTION_DAYS.
+func (c *PurgeTrashedCommand) Validate(parameters console.CommandParameter) error {
+	c.days = utils.Getenv("SOFT_DELETE_RETENTION_DAYS", 30)
+
+	if days, ok := parameters["days"]; ok {
+		value, err := strconv.Atoi(fmt.Sprint(days))
+		if err != nil {
+			return errors.New("Invalid parameter `days` %v", days)
+		}
+		c.days = value
+	}
+
+	if c.days < 0 {
+		return errors.New("Retention days must not be negative")
+	}
+
+	return nil
+}
+
+// Handle Process command.
+func (c *PurgeTrashedCommand) Handle() {
+	before := time.Now().AddDate(0, 0, -c.days)
+
+	for table, purge := range trashedPurgers {
+		total, err := purge(before)
+		if err != nil {
+			log.Errorf("PurgeTrashedCommand :: Can not purge table `%s`: %v", table, err)
+
+			continue
+		}
+
+		log.Infof("PurgeTrashedCommand :: Purged %d rows of table `%s` deleted before %s", total, table, before.Format("2006-01-02 15:04:05"))
+	}
+
+	log.Infof("PurgeTrashedCommand :: Run at %s", time.Now().Format("2006-01-02 15:04:05"))
+}
@@ -0,0 +1,220 @@
+package generate
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"github.com/gflydev/core/log"
+	"go/format"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"text/template"
+)
+
+// ---------------------------------------------------------------
+// 					Generator modes.
+// ./artisan make:model Product --from-db
+// ---------------------------------------------------------------
+
+// Modes of artisan.
+const (
+	ModeModel        = "make:model"
+	ModeRepository   = "make:repository"
+	ModeApi          = "make:api"
+	ModePage         = "make:page"
+	ModeCommand      = "make:command"
+	ModeTask         = "make:task"
+	ModeJob          = "make:job"
+	ModeNotification = "make:notification"
+	ModeMigration    = "make:migration"
+)
+
+// Modes all generator modes.
+var Modes = []string{
+	ModeModel,
+	ModeRepository,
+	ModeApi,
+	ModePage,
+	ModeCommand,
+	ModeTask,
+	ModeJob,
+	ModeNotification,
+	ModeMigration,
+}
+
+// Flags of generator modes.
+const (
+	forceFlag  = "--force"
+	fromDBFlag = "--from-db"
+	tableFlag  = "--table="
+)
+
+//go:embed stubs/*.tmpl
+var stubs embed.FS
+
+// Options arguments of a generator mode.
+type Options struct {
+	Name   string // Name of generated type. Optional "<package>/" prefix for APIs and pages.
+	Table  string // Table of model, default is plural snake case of name.
+	FromDB bool   // Derive model fields from table columns.
+	Force  bool   // Overwrite existing files.
+}
+
+// parseOptions get options from arguments after mode.
+func parseOptions(args []string) (Options, error) {
+	var options Options
+
+	for _, arg := range args {
+		switch {
+		case arg == forceFlag:
+			options.Force = true
+		case arg == fromDBFlag:
+			options.FromDB = true
+		case strings.HasPrefix(arg, tableFlag):
+			options.Table = strings.TrimPrefix(arg, tableFlag)
+		case strings.HasPrefix(arg, "--"):
+			return options, fmt.Errorf("unknown flag %s", arg)
+		case options.Name == "":
+			options.Name = arg
+		default:
+			return options, fmt.Errorf("unexpected argument %s", arg)
+		}
+	}
+
+	if options.Name == "" {
+		return options, errors.New("missing name")
+	}
+
+	return options, nil
+}
+
+// NeedsDatabase tell whether a generator run with arguments `args` after mode reads the database,
+// that is only with --from-db.
+func NeedsDatabase(args []string) bool {
+	return slices.Contains(args, fromDBFlag)
+}
+
+// Run run a generator mode with its arguments. Files are written relative to current directory,
+// which must be the project root.
+//
+//	./artisan make:repository Product
+func Run(args []string) error {
+	if _, err := os.Stat("go.mod"); err != nil {
+		return errors.New("run generators from the project root")
+	}
+
+	options, err := parseOptions(args[1:])
+	if err != nil {
+		return fmt.Errorf("%w, usage: %s <Name> [flags]", err, args[0])
+	}
+
+	var files []file
+	switch args[0] {
+	case ModeModel:
+		files, err = modelFiles(options)
+	case ModeRepository:
+		files = repositoryFiles(options)
+	case ModeApi:
+		files = apiFiles(options)
+	case ModePage:
+		files = pageFiles(options)
+	case ModeCommand:
+		files = commandFiles(options)
+	case ModeTask:
+		files = taskFiles(options)
+	case ModeJob:
+		files = jobFiles(options)
+	case ModeNotification:
+		files = notificationFiles(options)
+	case ModeMigration:
+		files, err = migrationFiles(options)
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, f := range files {
+		if err = f.write(options.Force); err != nil {
+			return err
+		}
+		log.Infof("Created %s", f.path)
+	}
+
+	if args[0] == ModeRepository {
+		if err = registerRepository(repositoryInit, NewName(options.Name)); err != nil {
+			return err
+		}
+		log.Infof("Registered %sRepository in %s", NewName(options.Name).Pascal, repositoryInit)
+	}
+
+	return nil
+}
+
+// ---------------------------------------------------------------
+// 					Template rendering.
+// ---------------------------------------------------------------
+
+// file a file to generate from a stub.
+type file struct {
+	path string
+	stub string
+	data any
+}
+
+// funcs template functions of stubs.
+var funcs = template.FuncMap{
+	"banner": banner,
+}
+
+// write render stub into path. Go sources are formatted by gofmt.
+func (f file) write(force bool) error {
+	if _, err := os.Stat(f.path); err == nil && !force {
+		return fmt.Errorf("%s already exists, use %s to overwrite", f.path, forceFlag)
+	}
+
+	content, err := stubs.ReadFile("stubs/" + f.stub)
+	if err != nil {
+		return err
+	}
+
+	// Stubs of views and SQL keep {{ }} for pongo, use [[ ]] for generator.
+	tmpl := template.New(f.stub).Funcs(funcs)
+	if !strings.HasSuffix(f.stub, ".go.tmpl") {
+		tmpl = tmpl.Delims("[[", "]]")
+	}
+	if tmpl, err = tmpl.Parse(string(content)); err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	if err = tmpl.Execute(&buf, f.data); err != nil {
+		return err
+	}
+
+	output := buf.Bytes()
+	if strings.HasSuffix(f.path, ".go") {
+		if output, err = format.Source(output); err != nil {
+			return fmt.Errorf("format %s: %w", f.path, err)
+		}
+	}
+
+	if err = os.MkdirAll(filepath.Dir(f.path), 0o755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(f.path, output, 0o644)
+}
+
+// banner get a section banner of title, as the ones of models, repositories and controllers.
+func banner(title string) string {
+	const width = 68
+
+	bar := strings.Repeat("=", width)
+	left := (width - len(title) - 1) / 2
+	right := width - len(title) - 2 - left
+
+	return fmt.Sprintf("// %s\n// %s %s %s\n// %s", bar, bar[:left], title, bar[:right], bar)
+}
@@ -0,0 +1,64 @@
+package generate
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestBanner(t *testing.T) {
+	// Banner of app/domain/repository/init.go
+	want := "// ====================================================================\n" +
+		"// ======================== Repository factory ========================\n" +
+		"// ===================================================================="
+	if got := banner("Repository factory"); got != want {
+		t.Fatalf("expected\n%s\ngot\n%s", want, got)
+	}
+
+	for _, title := range []string{"Data Types", "Odd title", "Address API"} {
+		for i, line := range strings.Split(banner(title), "\n") {
+			if len(line) != 71 {
+				t.Fatalf("%q line %d: expected 71 characters, got %d: %s", title, i, len(line), line)
+			}
+		}
+	}
+}
+
+func TestBannerMatchesModels(t *testing.T) {
+	content, err := os.ReadFile("../../domain/models/user_model.go")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.Contains(string(content), banner("Data Types")) {
+		t.Fatal("expected banner to match the Data Types banner of models")
+	}
+}
+
+func TestNeedsDatabase(t *testing.T) {
+	if !NeedsDatabase([]string{"Product", "--from-db"}) {
+		t.Fatal("expected --from-db to need the database")
+	}
+
+	if NeedsDatabase([]string{"Product", "--force"}) {
+		t.Fatal("expected no database without --from-db")
+	}
+}
+
+func TestParseOptions(t *testing.T) {
+	options, err := parseOptions([]string{"Product", "--from-db", "--table=items", "--force"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := Options{Name: "Product", Table: "items", FromDB: true, Force: true}
+	if options != want {
+		t.Fatalf("expected %+v, got %+v", want, options)
+	}
+
+	for _, args := range [][]string{nil, {"--force"}, {"Product", "--unknown"}, {"Product", "Other"}} {
+		if _, err = parseOptions(args); err == nil {
+			t.Fatalf("%v: expected an error", args)
+		}
+	}
+}
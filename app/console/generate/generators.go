@@ -0,0 +1,244 @@
+package generate
+
+import (
+	"fmt"
+	"gfly/database/migrations"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ---------------------------------------------------------------
+// 					Generator data.
+// ---------------------------------------------------------------
+
+// Folders of generated files.
+const (
+	modelDir        = "app/domain/models"
+	repositoryDir   = "app/domain/repository"
+	repositoryInit  = "app/domain/repository/init.go"
+	apiDir          = "app/http/controllers/api"
+	pageDir         = "app/http/controllers/page"
+	commandDir      = "app/console/commands"
+	taskDir         = "app/console/queues"
+	jobDir          = "app/console/schedules"
+	notificationDir = "app/notifications"
+	viewDir         = "resources/views"
+	migrationDir    = "database/migrations"
+)
+
+// data template data of stubs.
+type data struct {
+	Name
+	Package  string
+	Tag      string
+	Title    string
+	Fields   []Field
+	UsesSQL  bool
+	UsesTime bool
+}
+
+// newData get template data of a name.
+func newData(name Name) data {
+	title := name.Words
+	if title != "" {
+		title = strings.ToUpper(title[:1]) + title[1:]
+	}
+
+	return data{Name: name, Title: title}
+}
+
+// splitPackage split "<package>/<Name>" into package and name.
+func splitPackage(name, defaultPackage string) (string, string) {
+	if pkg, typeName, ok := strings.Cut(name, "/"); ok {
+		return NewName(pkg).Snake, typeName
+	}
+
+	return defaultPackage, name
+}
+
+// ---------------------------------------------------------------
+// 					Generators.
+// ---------------------------------------------------------------
+
+// modelFiles model app/domain/models/<name>_model.go. Fields come from table columns with --from-db.
+func modelFiles(options Options) ([]file, error) {
+	d := newData(NewName(options.Name))
+	if options.Table != "" {
+		d.Table = options.Table
+	}
+
+	d.Fields = defaultFields
+	if options.FromDB {
+		fields, err := introspect(d.Table)
+		if err != nil {
+			return nil, err
+		}
+		d.Fields = fields
+	}
+
+	for _, field := range d.Fields {
+		d.UsesSQL = d.UsesSQL || strings.HasPrefix(field.Type, "sql.")
+		d.UsesTime = d.UsesTime || field.Type == "time.Time"
+	}
+
+	return []file{{path: filepath.Join(modelDir, d.Snake+"_model.go"), stub: "model.go.tmpl", data: d}}, nil
+}
+
+// repositoryFiles repository app/domain/repository/<name>_repository.go of model <Name>.
+func repositoryFiles(options Options) []file {
+	d := newData(NewName(options.Name))
+
+	return []file{{path: filepath.Join(repositoryDir, d.Snake+"_repository.go"), stub: "repository.go.tmpl", data: d}}
+}
+
+// apiFiles API app/http/controllers/api/[<package>/]<name>_api.go.
+func apiFiles(options Options) []file {
+	pkg, name := splitPackage(options.Name, "api")
+	d := newData(NewName(name))
+	d.Package = pkg
+	d.Tag = "Misc"
+
+	dir := apiDir
+	if pkg != "api" {
+		dir = filepath.Join(apiDir, pkg)
+		d.Tag = newData(NewName(NewName(pkg).Table)).Title
+	}
+
+	return []file{{path: filepath.Join(dir, d.Snake+"_api.go"), stub: "api.go.tmpl", data: d}}
+}
+
+// pageFiles page app/http/controllers/page/[<package>/]<name>_page.go and its view.
+func pageFiles(options Options) []file {
+	pkg, name := splitPackage(options.Name, "page")
+	d := newData(NewName(name))
+	d.Package = pkg
+
+	dir := pageDir
+	if pkg != "page" {
+		dir = filepath.Join(pageDir, pkg)
+	}
+
+	return []file{
+		{path: filepath.Join(dir, d.Snake+"_page.go"), stub: "page.go.tmpl", data: d},
+		{path: filepath.Join(viewDir, d.Snake+".tpl"), stub: "page.tpl.tmpl", data: d},
+	}
+}
+
+// commandFiles command app/console/commands/<name>_command.go registered as <name>.
+func commandFiles(options Options) []file {
+	d := newData(NewName(options.Name))
+
+	return []file{{path: filepath.Join(commandDir, d.Snake+"_command.go"), stub: "command.go.tmpl", data: d}}
+}
+
+// taskFiles queue task app/console/queues/<name>_task.go registered as <name>.
+func taskFiles(options Options) []file {
+	d := newData(NewName(options.Name))
+
+	return []file{{path: filepath.Join(taskDir, d.Snake+"_task.go"), stub: "task.go.tmpl", data: d}}
+}
+
+// jobFiles scheduled job app/console/schedules/<name>_job.go.
+func jobFiles(options Options) []file {
+	d := newData(NewName(options.Name))
+
+	return []file{{path: filepath.Join(jobDir, d.Snake+"_job.go"), stub: "job.go.tmpl", data: d}}
+}
+
+// notificationFiles notification app/notifications/<name>.go and its mail view.
+func notificationFiles(options Options) []file {
+	d := newData(NewName(options.Name))
+
+	return []file{
+		{path: filepath.Join(notificationDir, d.Snake+".go"), stub: "notification.go.tmpl", data: d},
+		{path: filepath.Join(viewDir, "mails", d.Snake+".tpl"), stub: "mail.tpl.tmpl", data: d},
+	}
+}
+
+// migrationFiles up and down SQL files of next migration version, in the directory run by the migrator.
+func migrationFiles(options Options) ([]file, error) {
+	d := newData(NewName(options.Name))
+
+	dir := filepath.Join(migrationDir, migrations.Dir)
+	version, err := nextVersion(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := filepath.Join(dir, fmt.Sprintf("%06d_%s", version, d.Snake))
+
+	return []file{
+		{path: prefix + ".up.sql", stub: "migration.up.sql.tmpl", data: d},
+		{path: prefix + ".down.sql", stub: "migration.down.sql.tmpl", data: d},
+	}, nil
+}
+
+// nextVersion get the version following the latest migration of dir.
+func nextVersion(dir string) (int, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return 0, err
+	}
+
+	latest := 0
+	for _, entry := range entries {
+		prefix, _, _ := strings.Cut(entry.Name(), "_")
+		if version, e := strconv.Atoi(prefix); e == nil && version > latest {
+			latest = version
+		}
+	}
+
+	return latest + 1, nil
+}
+
+// ---------------------------------------------------------------
+// 					Repository pool.
+// ---------------------------------------------------------------
+
+// Blocks of repository pool in app/domain/repository/init.go.
+var (
+	repositoriesStruct = regexp.MustCompile(`(?s)type Repositories struct \{\n(.*?)\n}`)
+	repositoriesPool   = regexp.MustCompile(`(?s)var Pool = &Repositories\{\n(.*?)\n}`)
+)
+
+// registerRepository add interface and implementation of repository <Name> into repository.Pool.
+func registerRepository(initFile string, name Name) error {
+	content, err := os.ReadFile(initFile)
+	if err != nil {
+		return err
+	}
+	source := string(content)
+
+	iface := "I" + name.Pascal + "Repository"
+	impl := "&" + name.Pascal + "Repository{},"
+
+	source, err = appendLine(source, repositoriesStruct, iface)
+	if err != nil {
+		return err
+	}
+	if source, err = appendLine(source, repositoriesPool, impl); err != nil {
+		return err
+	}
+
+	return os.WriteFile(initFile, []byte(source), 0o644)
+}
+
+// appendLine append a tab indented line at the end of block matched by pattern, unless it is there.
+func appendLine(source string, pattern *regexp.Regexp, line string) (string, error) {
+	match := pattern.FindStringSubmatchIndex(source)
+	if match == nil {
+		return source, fmt.Errorf("%s not found in %s", pattern, path.Base(repositoryInit))
+	}
+
+	body := source[match[2]:match[3]]
+	if slices.Contains(strings.Fields(body), line) {
+		return source, nil
+	}
+
+	return source[:match[3]] + "\n\t" + line + source[match[3]:], nil
+}
@@ -0,0 +1,95 @@
+package generate
+
+import (
+	"go/format"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// initSource repository pool as in app/domain/repository/init.go.
+const initSource = `package repository
+
+// Repositories struct for collect all app repositories.
+type Repositories struct {
+	IRoleRepository
+	IUserRepository
+}
+
+// Pool a repository pool to store all
+var Pool = &Repositories{
+	&RoleRepository{},
+	&UserRepository{},
+}
+`
+
+// writeInit write source into an init.go of a temporary directory.
+func writeInit(t *testing.T, source string) string {
+	t.Helper()
+
+	initFile := filepath.Join(t.TempDir(), "init.go")
+	if err := os.WriteFile(initFile, []byte(source), 0o644); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	return initFile
+}
+
+func TestRegisterRepository(t *testing.T) {
+	initFile := writeInit(t, initSource)
+
+	// Registering twice must not duplicate the lines
+	for range 2 {
+		if err := registerRepository(initFile, NewName("product-category")); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	content, _ := os.ReadFile(initFile)
+	want := strings.Replace(initSource, "\tIUserRepository\n", "\tIUserRepository\n\tIProductCategoryRepository\n", 1)
+	want = strings.Replace(want, "\t&UserRepository{},\n", "\t&UserRepository{},\n\t&ProductCategoryRepository{},\n", 1)
+	if string(content) != want {
+		t.Fatalf("expected\n%s\ngot\n%s", want, content)
+	}
+
+	if formatted, err := format.Source(content); err != nil || string(formatted) != string(content) {
+		t.Fatalf("expected gofmt formatted source, got error %v", err)
+	}
+}
+
+func TestRegisterRepositoryErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		initFile string
+	}{
+		{"missing file", filepath.Join(t.TempDir(), "init.go")},
+		{"missing pool", writeInit(t, strings.Split(initSource, "// Pool")[0])},
+		{"missing struct", writeInit(t, "package repository\n\nvar Pool = &Repositories{\n\t&RoleRepository{},\n}\n")},
+	}
+
+	for _, tt := range tests {
+		if err := registerRepository(tt.initFile, NewName("Product")); err == nil {
+			t.Fatalf("%s: expected an error", tt.name)
+		}
+	}
+}
+
+func TestRegisterRepositoryOfProject(t *testing.T) {
+	content, err := os.ReadFile("../../domain/repository/init.go")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	initFile := writeInit(t, string(content))
+	if err = registerRepository(initFile, NewName("Product")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	registered, _ := os.ReadFile(initFile)
+	for _, line := range []string{"\tIProductRepository\n}", "\t&ProductRepository{},\n}"} {
+		if !strings.Contains(string(registered), line) {
+			t.Fatalf("expected %q at the end of its block, got\n%s", line, registered)
+		}
+	}
+}
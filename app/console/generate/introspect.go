@@ -0,0 +1,118 @@
+package generate
+
+import (
+	"errors"
+	"fmt"
+	"gfly/app/domain/repository"
+	"strings"
+)
+
+// ---------------------------------------------------------------
+// 					Model fields.
+// ---------------------------------------------------------------
+
+// Field a field of a generated model.
+type Field struct {
+	Name    string
+	Type    string
+	Column  string
+	Primary bool
+}
+
+// defaultFields fields of a new model without existing table.
+var defaultFields = []Field{
+	{Name: "ID", Type: "int", Column: "id", Primary: true},
+	{Name: "CreatedAt", Type: "time.Time", Column: "created_at"},
+	{Name: "UpdatedAt", Type: "sql.NullTime", Column: "updated_at"},
+}
+
+// column a column of table in information_schema.
+type column struct {
+	Name     string `db:"column_name"`
+	DataType string `db:"data_type"`
+	Nullable string `db:"is_nullable"`
+	Primary  bool   `db:"is_primary"`
+}
+
+// introspect get model fields from columns of given table in current database.
+func introspect(table string) ([]Field, error) {
+	db := repository.DB()
+	if db == nil {
+		return nil, errors.New("database is not loaded, register it with repository.Driver")
+	}
+
+	var columns []column
+	if err := db.Select(&columns, `
+		SELECT c.column_name, c.data_type, c.is_nullable,
+		       EXISTS (
+		           SELECT 1 FROM information_schema.table_constraints t
+		           JOIN information_schema.key_column_usage k
+		             ON k.constraint_name = t.constraint_name AND k.table_schema = t.table_schema
+		           WHERE t.constraint_type = 'PRIMARY KEY'
+		             AND t.table_schema = c.table_schema
+		             AND t.table_name = c.table_name
+		             AND k.column_name = c.column_name
+		       ) AS is_primary
+		FROM information_schema.columns c
+		WHERE c.table_schema = current_schema() AND c.table_name = $1
+		ORDER BY c.ordinal_position`, table); err != nil {
+		return nil, err
+	}
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("table %q does not exist", table)
+	}
+
+	fields := make([]Field, 0, len(columns))
+	for _, c := range columns {
+		fields = append(fields, Field{
+			Name:    fieldName(c.Name),
+			Type:    goType(c.DataType, c.Nullable == "YES" && !c.Primary),
+			Column:  c.Name,
+			Primary: c.Primary,
+		})
+	}
+
+	return fields, nil
+}
+
+// initialisms upper case words in Go field names.
+var initialisms = map[string]string{"id": "ID", "url": "URL", "ip": "IP", "api": "API", "uuid": "UUID", "json": "JSON"}
+
+// fieldName get Go field name of column.
+func fieldName(column string) string {
+	var name strings.Builder
+	for _, word := range splitWords(column) {
+		if initialism, ok := initialisms[word]; ok {
+			name.WriteString(initialism)
+		} else {
+			name.WriteString(strings.ToUpper(word[:1]) + word[1:])
+		}
+	}
+
+	return name.String()
+}
+
+// goType get Go type of a PostgreSQL data type.
+func goType(dataType string, nullable bool) string {
+	var typ, nullType string
+
+	switch {
+	case strings.Contains(dataType, "int"):
+		typ, nullType = "int", "sql.NullInt64"
+	case dataType == "boolean":
+		typ, nullType = "bool", "sql.NullBool"
+	case strings.HasPrefix(dataType, "timestamp"), dataType == "date":
+		typ, nullType = "time.Time", "sql.NullTime"
+	case dataType == "numeric", dataType == "real", dataType == "double precision":
+		typ, nullType = "float64", "sql.NullFloat64"
+	default:
+		// Character types, enums (USER-DEFINED), json, uuid...
+		typ, nullType = "string", "sql.NullString"
+	}
+
+	if nullable {
+		return nullType
+	}
+
+	return typ
+}
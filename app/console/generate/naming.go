@@ -0,0 +1,110 @@
+package generate
+
+import (
+	"strings"
+	"unicode"
+)
+
+// ---------------------------------------------------------------
+// 					Naming conventions.
+// ---------------------------------------------------------------
+
+// Name forms of a generated type name.
+//
+//	SendReport => Pascal SendReport, Camel sendReport, Snake send_report, Kebab send-report, Table send_reports
+type Name struct {
+	Pascal string
+	Camel  string
+	Snake  string
+	Kebab  string
+	Table  string
+	Words  string
+}
+
+// NewName get forms of a name given as PascalCase, camelCase, snake_case or kebab-case.
+func NewName(name string) Name {
+	words := splitWords(name)
+
+	var pascal strings.Builder
+	for _, word := range words {
+		pascal.WriteString(strings.ToUpper(word[:1]) + word[1:])
+	}
+
+	n := Name{
+		Pascal: pascal.String(),
+		Snake:  strings.Join(words, "_"),
+		Kebab:  strings.Join(words, "-"),
+		Words:  strings.Join(words, " "),
+	}
+	if n.Pascal != "" {
+		n.Camel = strings.ToLower(n.Pascal[:1]) + n.Pascal[1:]
+	}
+	if len(words) > 0 {
+		n.Table = strings.Join(append(words[:len(words)-1:len(words)-1], plural(words[len(words)-1])), "_")
+	}
+
+	return n
+}
+
+// Article get "a" or "an" of the name. A "u" followed by a consonant then a vowel sounds "you", as in
+// "a user" or "a unit", but not in "an update".
+func (n Name) Article() string {
+	words := n.Words
+	if words == "" || !isVowel(words[0]) {
+		return "a"
+	}
+
+	if words[0] == 'u' && len(words) > 2 && !isVowel(words[1]) && isVowel(words[2]) {
+		return "a"
+	}
+
+	return "an"
+}
+
+// isVowel check a lower case letter is a vowel.
+func isVowel(c byte) bool {
+	return strings.IndexByte("aeiou", c) >= 0
+}
+
+// splitWords split name into lower case words.
+func splitWords(name string) []string {
+	var words []string
+	var word []rune
+
+	flush := func() {
+		if len(word) > 0 {
+			words = append(words, strings.ToLower(string(word)))
+			word = word[:0]
+		}
+	}
+
+	runes := []rune(name)
+	for i, r := range runes {
+		switch {
+		case r == '_' || r == '-' || r == ' ' || r == '.':
+			flush()
+		case unicode.IsUpper(r) && i > 0 && (unicode.IsLower(runes[i-1]) ||
+			(i+1 < len(runes) && unicode.IsLower(runes[i+1]) && unicode.IsUpper(runes[i-1]))):
+			flush()
+			word = append(word, r)
+		default:
+			word = append(word, r)
+		}
+	}
+	flush()
+
+	return words
+}
+
+// plural get the english plural of a lower case word.
+func plural(word string) string {
+	switch {
+	case strings.HasSuffix(word, "y") && len(word) > 1 && !strings.ContainsRune("aeiou", rune(word[len(word)-2])):
+		return word[:len(word)-1] + "ies"
+	case strings.HasSuffix(word, "s"), strings.HasSuffix(word, "x"), strings.HasSuffix(word, "z"),
+		strings.HasSuffix(word, "ch"), strings.HasSuffix(word, "sh"):
+		return word + "es"
+	}
+
+	return word + "s"
+}
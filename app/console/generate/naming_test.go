@@ -0,0 +1,48 @@
+package generate
+
+import "testing"
+
+func TestNewName(t *testing.T) {
+	tests := []struct {
+		input string
+		want  Name
+	}{
+		{"ProductCategory", Name{"ProductCategory", "productCategory", "product_category", "product-category", "product_categories", "product category"}},
+		{"productCategory", Name{"ProductCategory", "productCategory", "product_category", "product-category", "product_categories", "product category"}},
+		{"product_category", Name{"ProductCategory", "productCategory", "product_category", "product-category", "product_categories", "product category"}},
+		{"product-category", Name{"ProductCategory", "productCategory", "product_category", "product-category", "product_categories", "product category"}},
+		{"HTTPRequest", Name{"HttpRequest", "httpRequest", "http_request", "http-request", "http_requests", "http request"}},
+		{"Address", Name{"Address", "address", "address", "address", "addresses", "address"}},
+		{"Box", Name{"Box", "box", "box", "box", "boxes", "box"}},
+		{"Key", Name{"Key", "key", "key", "key", "keys", "key"}},
+		{"Branch", Name{"Branch", "branch", "branch", "branch", "branches", "branch"}},
+		{"", Name{}},
+	}
+
+	for _, tt := range tests {
+		if got := NewName(tt.input); got != tt.want {
+			t.Fatalf("%q: expected %+v, got %+v", tt.input, tt.want, got)
+		}
+	}
+}
+
+func TestNameArticle(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"Order", "an"},
+		{"UserRole", "a"},
+		{"Unit", "a"},
+		{"Update", "an"},
+		{"Umbrella", "an"},
+		{"invoice_item", "an"},
+		{"", "a"},
+	}
+
+	for _, tt := range tests {
+		if got := NewName(tt.input).Article(); got != tt.want {
+			t.Fatalf("%q: expected %q, got %q", tt.input, tt.want, got)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package migrate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"gfly/app/constant"
+	"gfly/app/domain/repository"
+	"gfly/database/migrations"
+	"github.com/gflydev/core/utils"
+	"os"
+	"slices"
+	"strconv"
+)
+
+// ---------------------------------------------------------------
+// 					Migrate modes.
+// ./artisan migrate:up
+// ./artisan migrate:down [n]
+// ./artisan migrate:status
+// ./artisan migrate:rollback
+// ./artisan migrate:fresh [--force]
+// ---------------------------------------------------------------
+
+// Migrate modes of artisan.
+const (
+	ModeUp       = "migrate:up"
+	ModeDown     = "migrate:down"
+	ModeStatus   = "migrate:status"
+	ModeRollback = "migrate:rollback"
+	ModeFresh    = "migrate:fresh"
+)
+
+// Modes all migrate modes.
+var Modes = []string{ModeUp, ModeDown, ModeStatus, ModeRollback, ModeFresh}
+
+// forceFlag flag allowing destructive modes in production.
+const forceFlag = "--force"
+
+// Run run a migrate mode with its arguments, on the database connection loaded from `.env` settings.
+//
+//	./artisan migrate:down 2
+func Run(args []string) error {
+	db := repository.DB()
+	if db == nil {
+		return errors.New("database is not loaded, register it with repository.Driver")
+	}
+
+	list, err := Load(migrations.FS, migrations.Dir)
+	if err != nil {
+		return err
+	}
+
+	migrator := NewMigrator(db, list)
+	ctx := context.Background()
+
+	switch args[0] {
+	case ModeUp:
+		return migrator.Up(ctx)
+	case ModeDown:
+		steps := 1
+		if len(args) > 1 {
+			if steps, err = strconv.Atoi(args[1]); err != nil || steps < 1 {
+				return fmt.Errorf("invalid number of migrations %q", args[1])
+			}
+		}
+
+		return migrator.Down(ctx, steps)
+	case ModeStatus:
+		return migrator.Status(ctx, os.Stdout)
+	case ModeRollback:
+		return migrator.Rollback(ctx)
+	case ModeFresh:
+		if err = allowFresh(utils.Getenv("APP_ENV", constant.EnvLocal), args[1:]); err != nil {
+			return err
+		}
+
+		return migrator.Fresh(ctx)
+	}
+
+	return fmt.Errorf("unknown mode %q", args[0])
+}
+
+// productionEnvs APP_ENV values of production.
+var productionEnvs = []string{constant.EnvProd, "production"}
+
+// allowFresh refuse migrate:fresh, which drops the whole schema, in production unless --force is given.
+func allowFresh(env string, args []string) error {
+	if slices.Contains(productionEnvs, env) && !slices.Contains(args, forceFlag) {
+		return fmt.Errorf("%s drops all tables, run it with %s in production", ModeFresh, forceFlag)
+	}
+
+	return nil
+}
@@ -0,0 +1,25 @@
+package migrate
+
+import "testing"
+
+func TestAllowFresh(t *testing.T) {
+	tests := []struct {
+		env     string
+		args    []string
+		allowed bool
+	}{
+		{env: "local", allowed: true},
+		{env: "stag", allowed: true},
+		{env: "prod", allowed: false},
+		{env: "production", allowed: false},
+		{env: "prod", args: []string{"--force"}, allowed: true},
+		{env: "production", args: []string{"--force"}, allowed: true},
+	}
+
+	for _, test := range tests {
+		err := allowFresh(test.env, test.args)
+		if allowed := err == nil; allowed != test.allowed {
+			t.Fatalf("%s %v: expected allowed %v, got error %v", test.env, test.args, test.allowed, err)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package migrate
+
+import (
+	"gfly/database/migrations"
+	"testing"
+	"testing/fstest"
+)
+
+func TestLoadSortsByVersion(t *testing.T) {
+	fsys := fstest.MapFS{
+		"sql/10_add_index.up.sql":      {Data: []byte("CREATE INDEX")},
+		"sql/10_add_index.down.sql":    {Data: []byte("DROP INDEX")},
+		"sql/2_create_users.up.sql":    {Data: []byte("CREATE TABLE")},
+		"sql/2_create_users.down.sql":  {Data: []byte("DROP TABLE")},
+		"sql/000001_init.up.sql":       {Data: []byte("CREATE SCHEMA")},
+		"sql/000001_init.down.sql":     {Data: []byte("DROP SCHEMA")},
+		"sql/README.md":                {Data: []byte("ignored")},
+		"sql/3_draft.sql":              {Data: []byte("ignored")},
+		"sql/nested/4_nested.up.sql":   {Data: []byte("ignored")},
+		"sql/nested/4_nested.down.sql": {Data: []byte("ignored")},
+	}
+
+	list, err := Load(fsys, "sql")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []Migration{
+		{Version: 1, Name: "init", Up: "CREATE SCHEMA", Down: "DROP SCHEMA"},
+		{Version: 2, Name: "create_users", Up: "CREATE TABLE", Down: "DROP TABLE"},
+		{Version: 10, Name: "add_index", Up: "CREATE INDEX", Down: "DROP INDEX"},
+	}
+	if len(list) != len(want) {
+		t.Fatalf("expected %d migrations, got %d: %+v", len(want), len(list), list)
+	}
+
+	for i := range want {
+		if list[i] != want[i] {
+			t.Fatalf("migration %d: expected %+v, got %+v", i, want[i], list[i])
+		}
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		fsys fstest.MapFS
+	}{
+		{"missing down file", fstest.MapFS{
+			"sql/1_init.up.sql": {Data: []byte("CREATE")},
+		}},
+		{"missing up file", fstest.MapFS{
+			"sql/1_init.down.sql": {Data: []byte("DROP")},
+		}},
+		{"two names for one version", fstest.MapFS{
+			"sql/1_init.up.sql":    {Data: []byte("CREATE")},
+			"sql/1_other.down.sql": {Data: []byte("DROP")},
+		}},
+		{"missing directory", fstest.MapFS{}},
+	}
+
+	for _, tt := range tests {
+		if _, err := Load(tt.fsys, "sql"); err == nil {
+			t.Fatalf("%s: expected an error", tt.name)
+		}
+	}
+}
+
+func TestLoadEmbeddedMigrations(t *testing.T) {
+	list, err := Load(migrations.FS, migrations.Dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(list) == 0 {
+		t.Fatal("expected embedded migrations")
+	}
+
+	for i := 1; i < len(list); i++ {
+		if list[i].Version <= list[i-1].Version {
+			t.Fatalf("expected increasing versions, got %d after %d", list[i].Version, list[i-1].Version)
+		}
+	}
+}
@@ -0,0 +1,387 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/gflydev/core/log"
+	"github.com/jmoiron/sqlx"
+	"hash/fnv"
+	"io"
+	"text/tabwriter"
+	"time"
+)
+
+// ---------------------------------------------------------------
+// 					Migrator.
+// ---------------------------------------------------------------
+
+// migrationsTable table recording applied migrations.
+const migrationsTable = "migrations"
+
+// legacyTable table of versions applied by the external `migrate` tool.
+const legacyTable = "schema_migrations"
+
+// legacyBatch batch of migrations imported from legacyTable, so Rollback can revert them.
+const legacyBatch = 1
+
+// Migrator apply and revert migrations on a PostgreSQL database. Every command holds an advisory lock,
+// so concurrent deploys run one after another, and every migration runs in its own transaction.
+type Migrator struct {
+	db         *sqlx.DB
+	migrations []Migration
+}
+
+// NewMigrator create a migrator of given migrations.
+func NewMigrator(db *sqlx.DB, migrations []Migration) *Migrator {
+	return &Migrator{
+		db:         db,
+		migrations: migrations,
+	}
+}
+
+// appliedMigration a row of the migrations table.
+type appliedMigration struct {
+	Version   int64
+	Name      string
+	Batch     int
+	AppliedAt time.Time
+}
+
+// Up apply all pending migrations in one batch.
+func (m *Migrator) Up(ctx context.Context) error {
+	return m.locked(ctx, func(conn *sql.Conn, applied map[int64]appliedMigration) error {
+		return m.applyPending(ctx, conn, applied)
+	})
+}
+
+// Down revert the last `steps` applied migrations.
+func (m *Migrator) Down(ctx context.Context, steps int) error {
+	return m.locked(ctx, func(conn *sql.Conn, applied map[int64]appliedMigration) error {
+		for i := len(m.migrations) - 1; i >= 0 && steps > 0; i-- {
+			migration := m.migrations[i]
+			if _, ok := applied[migration.Version]; !ok {
+				continue
+			}
+
+			if err := m.revert(ctx, conn, migration); err != nil {
+				return err
+			}
+			steps--
+		}
+
+		return nil
+	})
+}
+
+// Rollback revert all migrations of the last batch. Batch 0 holds migrations imported by older versions
+// of the migrator, it is rolled back like the others.
+func (m *Migrator) Rollback(ctx context.Context) error {
+	return m.locked(ctx, func(conn *sql.Conn, applied map[int64]appliedMigration) error {
+		batch := lastBatch(applied)
+		if len(applied) == 0 {
+			log.Info("Nothing to rollback")
+
+			return nil
+		}
+
+		for i := len(m.migrations) - 1; i >= 0; i-- {
+			migration := m.migrations[i]
+			if row, ok := applied[migration.Version]; !ok || row.Batch != batch {
+				continue
+			}
+
+			if err := m.revert(ctx, conn, migration); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// Fresh drop all objects of the current schema, then apply all migrations.
+func (m *Migrator) Fresh(ctx context.Context) error {
+	return m.locked(ctx, func(conn *sql.Conn, _ map[int64]appliedMigration) error {
+		log.Info("Dropping all tables")
+
+		_, err := conn.ExecContext(ctx, `DO $$
+DECLARE schema_name TEXT := current_schema();
+BEGIN
+	EXECUTE format('DROP SCHEMA %I CASCADE', schema_name);
+	EXECUTE format('CREATE SCHEMA %I', schema_name);
+END $$`)
+		if err != nil {
+			return err
+		}
+
+		if err = m.ensureTable(ctx, conn); err != nil {
+			return err
+		}
+
+		return m.applyPending(ctx, conn, map[int64]appliedMigration{})
+	})
+}
+
+// Status write a table of migrations with their state to `w`.
+func (m *Migrator) Status(ctx context.Context, w io.Writer) error {
+	return m.locked(ctx, func(_ *sql.Conn, applied map[int64]appliedMigration) error {
+		table := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		_, _ = fmt.Fprintln(table, "VERSION\tNAME\tSTATUS\tBATCH\tAPPLIED AT")
+
+		for _, migration := range m.migrations {
+			row, ok := applied[migration.Version]
+			if !ok {
+				_, _ = fmt.Fprintf(table, "%06d\t%s\tpending\t-\t-\n", migration.Version, migration.Name)
+
+				continue
+			}
+
+			_, _ = fmt.Fprintf(table, "%06d\t%s\tapplied\t%d\t%s\n",
+				migration.Version, migration.Name, row.Batch, row.AppliedAt.Format(time.DateTime))
+		}
+
+		return table.Flush()
+	})
+}
+
+// ---------------------------------------------------------------
+// 					Migration steps.
+// ---------------------------------------------------------------
+
+// applyPending apply migrations missing from `applied` in a new batch.
+func (m *Migrator) applyPending(ctx context.Context, conn *sql.Conn, applied map[int64]appliedMigration) error {
+	batch := lastBatch(applied) + 1
+	count := 0
+
+	for _, migration := range m.migrations {
+		if _, ok := applied[migration.Version]; ok {
+			continue
+		}
+
+		if err := m.apply(ctx, conn, migration, batch); err != nil {
+			return err
+		}
+		count++
+	}
+
+	if count == 0 {
+		log.Info("Nothing to migrate")
+	}
+
+	return nil
+}
+
+// apply run up SQL of a migration and record it, in one transaction.
+func (m *Migrator) apply(ctx context.Context, conn *sql.Conn, migration Migration, batch int) error {
+	start := time.Now()
+
+	err := inTransaction(ctx, conn, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, migration.Up); err != nil {
+			return err
+		}
+
+		_, err := tx.ExecContext(ctx,
+			"INSERT INTO "+migrationsTable+" (version, name, batch) VALUES ($1, $2, $3)",
+			migration.Version, migration.Name, batch)
+
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("migrate %06d_%s: %w", migration.Version, migration.Name, err)
+	}
+
+	log.Infof("Migrated %06d_%s (%v)", migration.Version, migration.Name, time.Since(start))
+
+	return nil
+}
+
+// revert run down SQL of a migration and forget it, in one transaction.
+func (m *Migrator) revert(ctx context.Context, conn *sql.Conn, migration Migration) error {
+	start := time.Now()
+
+	err := inTransaction(ctx, conn, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, migration.Down); err != nil {
+			return err
+		}
+
+		_, err := tx.ExecContext(ctx, "DELETE FROM "+migrationsTable+" WHERE version = $1", migration.Version)
+
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("revert %06d_%s: %w", migration.Version, migration.Name, err)
+	}
+
+	log.Infof("Reverted %06d_%s (%v)", migration.Version, migration.Name, time.Since(start))
+
+	return nil
+}
+
+// ---------------------------------------------------------------
+// 					Locking & bookkeeping.
+// ---------------------------------------------------------------
+
+// locked run `action` on a dedicated connection holding the migration advisory lock, with the
+// migrations table created and its applied migrations loaded.
+func (m *Migrator) locked(ctx context.Context, action func(conn *sql.Conn, applied map[int64]appliedMigration) error) error {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	key := lockKey()
+
+	var acquired bool
+	if err = conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1)", key).Scan(&acquired); err != nil {
+		return err
+	}
+	if !acquired {
+		log.Info("Waiting for another migration to finish")
+
+		if _, err = conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", key); err != nil {
+			return err
+		}
+	}
+	defer func() {
+		if _, e := conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", key); e != nil {
+			log.Errorf("Could not release migration lock: %v", e)
+		}
+	}()
+
+	if err = m.ensureTable(ctx, conn); err != nil {
+		return err
+	}
+
+	applied, err := appliedMigrations(ctx, conn)
+	if err != nil {
+		return err
+	}
+
+	return action(conn, applied)
+}
+
+// lockKey advisory lock key of migrations.
+func lockKey() int64 {
+	hash := fnv.New64a()
+	_, _ = hash.Write([]byte("gfly:" + migrationsTable))
+
+	return int64(hash.Sum64())
+}
+
+// ensureTable create the migrations table. On a database migrated by the external `migrate` tool,
+// its applied versions are imported as batch 1.
+func (m *Migrator) ensureTable(ctx context.Context, conn *sql.Conn) error {
+	var exists bool
+	if err := conn.QueryRowContext(ctx, "SELECT to_regclass($1) IS NOT NULL", migrationsTable).Scan(&exists); err != nil {
+		return err
+	}
+	if exists {
+		return nil
+	}
+
+	return inTransaction(ctx, conn, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, `CREATE TABLE `+migrationsTable+` (
+	version BIGINT PRIMARY KEY,
+	name VARCHAR(255) NOT NULL,
+	batch INT NOT NULL,
+	applied_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+)`)
+		if err != nil {
+			return err
+		}
+
+		return m.importLegacy(ctx, tx)
+	})
+}
+
+// importLegacy record migrations applied by the external `migrate` tool, which keeps only the last version.
+func (m *Migrator) importLegacy(ctx context.Context, tx *sql.Tx) error {
+	var exists bool
+	if err := tx.QueryRowContext(ctx, "SELECT to_regclass($1) IS NOT NULL", legacyTable).Scan(&exists); err != nil || !exists {
+		return err
+	}
+
+	var version int64
+	var dirty bool
+	err := tx.QueryRowContext(ctx, "SELECT version, dirty FROM "+legacyTable+" LIMIT 1").Scan(&version, &dirty)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if dirty {
+		return fmt.Errorf("version %d of table %s is dirty, fix it by hand first", version, legacyTable)
+	}
+
+	log.Infof("Importing migrations up to %06d from table %s", version, legacyTable)
+
+	for _, migration := range m.migrations {
+		if migration.Version > version {
+			break
+		}
+
+		_, err = tx.ExecContext(ctx,
+			"INSERT INTO "+migrationsTable+" (version, name, batch) VALUES ($1, $2, $3)",
+			migration.Version, migration.Name, legacyBatch)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// appliedMigrations get rows of the migrations table by version.
+func appliedMigrations(ctx context.Context, conn *sql.Conn) (map[int64]appliedMigration, error) {
+	rows, err := conn.QueryContext(ctx, "SELECT version, name, batch, applied_at FROM "+migrationsTable)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	applied := make(map[int64]appliedMigration)
+	for rows.Next() {
+		var row appliedMigration
+		if err = rows.Scan(&row.Version, &row.Name, &row.Batch, &row.AppliedAt); err != nil {
+			return nil, err
+		}
+
+		applied[row.Version] = row
+	}
+
+	return applied, rows.Err()
+}
+
+// lastBatch get the highest batch number of applied migrations.
+func lastBatch(applied map[int64]appliedMigration) int {
+	batch := 0
+	for _, row := range applied {
+		batch = max(batch, row.Batch)
+	}
+
+	return batch
+}
+
+// inTransaction run `action` in a transaction of `conn`, committed when it succeeds.
+func inTransaction(ctx context.Context, conn *sql.Conn, action func(tx *sql.Tx) error) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	if err = action(tx); err != nil {
+		_ = tx.Rollback()
+
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -15,7 +15,7 @@ import (
 
 // Auto-register task into queue.
 func init() {
-	console.RegisterTask(&HelloTask{}, "hello-world")
+	registerTask(&HelloTask{}, "hello-world")
 }
 
 // ---------------------------------------------------------------
@@ -25,12 +25,14 @@ func init() {
 // NewHelloTask Constructor HelloTask.
 func NewHelloTask(message string) (HelloTaskPayload, string) {
 	return HelloTaskPayload{
-		Message: message,
+		TaskMeta: NewTaskMeta(""),
+		Message:  message,
 	}, "hello-world"
 }
 
 // HelloTaskPayload Task payload.
 type HelloTaskPayload struct {
+	TaskMeta
 	Message string
 }
 
@@ -0,0 +1,82 @@
+package queues
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"gfly/app/notifications"
+	"github.com/gflydev/console"
+	"github.com/gflydev/notification"
+	"github.com/hibiken/asynq"
+	"time"
+)
+
+// ---------------------------------------------------------------
+// 					Register task.
+// ---------------------------------------------------------------
+
+// Auto-register task into queue.
+func init() {
+	registerTask(&ResetPasswordTask{}, "reset-password")
+}
+
+// ---------------------------------------------------------------
+// 					Task info.
+// ---------------------------------------------------------------
+
+// NewResetPasswordTask Constructor ResetPasswordTask.
+func NewResetPasswordTask(requestID, email, fullname, link string, expiresAt time.Time) (ResetPasswordTaskPayload, string) {
+	return ResetPasswordTaskPayload{
+		TaskMeta:  NewTaskMeta(requestID),
+		Email:     email,
+		Fullname:  fullname,
+		Link:      link,
+		ExpiresAt: expiresAt,
+	}, "reset-password"
+}
+
+// ResetPasswordTaskPayload Task payload.
+type ResetPasswordTaskPayload struct {
+	TaskMeta
+	Email     string
+	Fullname  string
+	Link      string
+	ExpiresAt time.Time
+}
+
+// ResetPasswordTask send reset password email out of request processing.
+type ResetPasswordTask struct {
+	console.Task
+}
+
+// Dequeue Handle a task in queue.
+func (t ResetPasswordTask) Dequeue(ctx context.Context, task *asynq.Task) error {
+	// Decode task payload
+	var payload ResetPasswordTaskPayload
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("json.Unmarshal failed: %v: %w", err, asynq.SkipRetry)
+	}
+
+	// Link is useless after expiration
+	if time.Now().After(payload.ExpiresAt) {
+		payload.Infof("Skip expired reset password email to %s", payload.Email)
+
+		return nil
+	}
+
+	// Process payload
+	if err := notification.Send(notifications.ResetPassword{
+		Email:     payload.Email,
+		Fullname:  payload.Fullname,
+		Link:      payload.Link,
+		ExpiresAt: payload.ExpiresAt,
+	}); err != nil {
+		payload.Errorf("Send reset password email to %s failed: %v", payload.Email, err)
+
+		return err
+	}
+
+	payload.Infof("Sent reset password email to %s", payload.Email)
+
+	return nil
+}
@@ -0,0 +1,52 @@
+package queues
+
+import (
+	"gfly/app/metrics"
+	"gfly/app/tracing"
+	"github.com/gflydev/console"
+	"github.com/gflydev/core/log"
+)
+
+// ---------------------------------------------------------------
+// 					Task registration.
+// ---------------------------------------------------------------
+
+// tasks registered tasks processed by Worker.
+var tasks = make(map[string]console.ITask)
+
+// registerTask register a task into queue with metrics and tracing of its runs.
+func registerTask(task console.ITask, name string) {
+	task = metrics.Task(name, tracing.Task(name, task))
+
+	tasks[name] = task
+	console.RegisterTask(task, name)
+}
+
+// ---------------------------------------------------------------
+// 					Task metadata.
+// ---------------------------------------------------------------
+
+// TaskMeta metadata of tasks dispatched while processing a request. Embed it in task payloads,
+// then queue logs can be tied back to the originating request by its ID, and the worker continues
+// the trace of the request.
+type TaskMeta struct {
+	RequestID   string
+	TraceParent string // Producer span, set by `tracing.DispatchTask`
+}
+
+// NewTaskMeta create metadata of a task dispatched by given request.
+func NewTaskMeta(requestID string) TaskMeta {
+	return TaskMeta{
+		RequestID: requestID,
+	}
+}
+
+// Infof log an info message tagged with the request ID.
+func (m TaskMeta) Infof(format string, args ...any) {
+	log.Infof("[%s] "+format, append([]any{m.RequestID}, args...)...)
+}
+
+// Errorf log an error message tagged with the request ID.
+func (m TaskMeta) Errorf(format string, args ...any) {
+	log.Errorf("[%s] "+format, append([]any{m.RequestID}, args...)...)
+}
@@ -0,0 +1,82 @@
+package queues
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"gfly/app/notifications"
+	"github.com/gflydev/console"
+	"github.com/gflydev/notification"
+	"github.com/hibiken/asynq"
+	"time"
+)
+
+// ---------------------------------------------------------------
+// 					Register task.
+// ---------------------------------------------------------------
+
+// Auto-register task into queue.
+func init() {
+	registerTask(&VerifyEmailTask{}, "verify-email")
+}
+
+// ---------------------------------------------------------------
+// 					Task info.
+// ---------------------------------------------------------------
+
+// NewVerifyEmailTask Constructor VerifyEmailTask.
+func NewVerifyEmailTask(requestID, email, fullname, link string, expiresAt time.Time) (VerifyEmailTaskPayload, string) {
+	return VerifyEmailTaskPayload{
+		TaskMeta:  NewTaskMeta(requestID),
+		Email:     email,
+		Fullname:  fullname,
+		Link:      link,
+		ExpiresAt: expiresAt,
+	}, "verify-email"
+}
+
+// VerifyEmailTaskPayload Task payload.
+type VerifyEmailTaskPayload struct {
+	TaskMeta
+	Email     string
+	Fullname  string
+	Link      string
+	ExpiresAt time.Time
+}
+
+// VerifyEmailTask send email verification out of request processing.
+type VerifyEmailTask struct {
+	console.Task
+}
+
+// Dequeue Handle a task in queue.
+func (t VerifyEmailTask) Dequeue(ctx context.Context, task *asynq.Task) error {
+	// Decode task payload
+	var payload VerifyEmailTaskPayload
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("json.Unmarshal failed: %v: %w", err, asynq.SkipRetry)
+	}
+
+	// Link is useless after expiration
+	if time.Now().After(payload.ExpiresAt) {
+		payload.Infof("Skip expired verification email to %s", payload.Email)
+
+		return nil
+	}
+
+	// Process payload
+	if err := notification.Send(notifications.VerifyEmail{
+		Email:     payload.Email,
+		Fullname:  payload.Fullname,
+		Link:      payload.Link,
+		ExpiresAt: payload.ExpiresAt,
+	}); err != nil {
+		payload.Errorf("Send verification email to %s failed: %v", payload.Email, err)
+
+		return err
+	}
+
+	payload.Infof("Sent verification email to %s", payload.Email)
+
+	return nil
+}
@@ -0,0 +1,78 @@
+package queues
+
+import (
+	"context"
+	"fmt"
+	"github.com/gflydev/core/log"
+	"github.com/gflydev/core/utils"
+	"github.com/hibiken/asynq"
+	"time"
+)
+
+// ---------------------------------------------------------------
+// 					Queue worker.
+// ---------------------------------------------------------------
+
+// Worker a queue worker processing registered tasks, like `console.StartQueueWorker`, which can be
+// shut down gracefully.
+type Worker struct {
+	server *asynq.Server
+}
+
+// StartWorker start a worker in background. On shutdown, running tasks get `shutdownTimeout` to finish,
+// then they are pushed back to queue.
+func StartWorker(shutdownTimeout time.Duration) (*Worker, error) {
+	server := asynq.NewServer(
+		asynq.RedisClientOpt{
+			Addr: fmt.Sprintf(
+				"%s:%d",
+				utils.Getenv("REDIS_HOST", "localhost"),
+				utils.Getenv("REDIS_PORT", 6379),
+			),
+			Password: utils.Getenv("REDIS_PASSWORD", ""),
+			DB:       utils.Getenv("REDIS_QUEUE_NUMBER", 0),
+		},
+		asynq.Config{
+			// Specify how many concurrent workers to use
+			Concurrency: utils.Getenv("QUEUE_CONCURRENCY", 10),
+			// Optional specify multiple queues (Queue type) with different priority.
+			Queues: map[string]int{
+				"critical": 6,
+				"default":  3,
+				"low":      1,
+			},
+			ShutdownTimeout: shutdownTimeout,
+		},
+	)
+
+	// mux maps a type to a handler
+	mux := asynq.NewServeMux()
+	for name, task := range tasks {
+		mux.HandleFunc(name, task.Dequeue)
+		log.Infof("Init queue task %s", name)
+	}
+
+	if err := server.Start(mux); err != nil {
+		return nil, err
+	}
+
+	return &Worker{server: server}, nil
+}
+
+// Shutdown stop pulling new tasks and wait for running ones, or until `ctx` is done.
+func (w *Worker) Shutdown(ctx context.Context) error {
+	w.server.Stop()
+
+	done := make(chan struct{})
+	go func() {
+		w.server.Shutdown()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
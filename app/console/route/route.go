@@ -0,0 +1,75 @@
+package route
+
+import (
+	"encoding/json"
+	"fmt"
+	"gfly/app/http/response"
+	"gfly/app/http/routes"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+)
+
+// ---------------------------------------------------------------
+// 					Route list mode.
+// ./artisan route:list --method=GET --prefix=/api --json
+// ---------------------------------------------------------------
+
+// ModeList mode of artisan.
+const ModeList = "route:list"
+
+// Flags of mode route:list.
+const (
+	methodFlag = "--method="
+	prefixFlag = "--prefix="
+	jsonFlag   = "--json"
+)
+
+// Run print routes of the application, with full path, handler type and middlewares.
+func Run(args []string) error {
+	var method, prefix string
+	asJSON := false
+
+	for _, arg := range args[1:] {
+		switch {
+		case strings.HasPrefix(arg, methodFlag):
+			method = strings.TrimPrefix(arg, methodFlag)
+		case strings.HasPrefix(arg, prefixFlag):
+			prefix = strings.TrimPrefix(arg, prefixFlag)
+		case arg == jsonFlag:
+			asJSON = true
+		default:
+			return fmt.Errorf("unknown argument %s, usage: %s [%s<METHOD>] [%s<PATH>] [%s]", arg, ModeList, methodFlag, prefixFlag, jsonFlag)
+		}
+	}
+
+	list := routes.Filter(routes.List(), method, prefix)
+
+	if asJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+
+		return encoder.Encode(list)
+	}
+
+	return writeTable(os.Stdout, list)
+}
+
+// writeTable write routes as an aligned table.
+func writeTable(w io.Writer, list []response.Route) error {
+	table := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	_, _ = fmt.Fprintln(table, "METHOD\tPATH\tHANDLER\tMIDDLEWARES")
+	for _, r := range list {
+		_, _ = fmt.Fprintf(table, "%s\t%s\t%s\t%s\n", r.Method, r.Path, r.Handler, strings.Join(r.Middlewares, ", "))
+	}
+
+	if err := table.Flush(); err != nil {
+		return err
+	}
+
+	_, err := fmt.Fprintf(w, "\n%d routes\n", len(list))
+
+	return err
+}
@@ -1,7 +1,6 @@
 package schedules
 
 import (
-	"github.com/gflydev/console"
 	"github.com/gflydev/core/log"
 	"time"
 )
@@ -12,7 +11,7 @@ import (
 
 // Auto-register job into scheduler.
 func init() {
-	console.RegisterJob(&HelloJob{})
+	registerJob(&HelloJob{})
 }
 
 // ---------------------------------------------------------------
@@ -0,0 +1,62 @@
+package schedules
+
+import (
+	"context"
+	"gfly/app/metrics"
+	"github.com/gflydev/console"
+	"github.com/gflydev/core/log"
+	"github.com/gflydev/core/utils"
+	"github.com/robfig/cron/v3"
+)
+
+// ---------------------------------------------------------------
+// 					Job registration.
+// ---------------------------------------------------------------
+
+// jobs registered jobs run by Scheduler.
+var jobs []console.IJob
+
+// registerJob register a job into scheduler with metrics of its runs.
+func registerJob(job console.IJob) {
+	job = metrics.Job(job)
+
+	jobs = append(jobs, job)
+	console.RegisterJob(job)
+}
+
+// ---------------------------------------------------------------
+// 					Scheduler.
+// ---------------------------------------------------------------
+
+// Scheduler a scheduler running registered jobs, like `console.StartScheduler`, which can be
+// shut down gracefully.
+type Scheduler struct {
+	cron *cron.Cron
+}
+
+// StartScheduler start a scheduler in background.
+func StartScheduler() (*Scheduler, error) {
+	c := cron.New(cron.WithSeconds())
+
+	for _, job := range jobs {
+		if _, err := c.AddFunc(job.GetTime(), job.Handle); err != nil {
+			return nil, err
+		}
+		log.Infof("Init schedule job %s", utils.ReflectType(job))
+	}
+
+	c.Start()
+
+	return &Scheduler{cron: c}, nil
+}
+
+// Shutdown stop scheduling new runs and wait for running jobs, or until `ctx` is done.
+// Running jobs are never interrupted.
+func (s *Scheduler) Shutdown(ctx context.Context) error {
+	select {
+	case <-s.cron.Stop().Done():
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
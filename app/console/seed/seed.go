@@ -0,0 +1,134 @@
+package seed
+
+import (
+	"fmt"
+	"gfly/app/constant"
+	"gfly/app/domain/repository"
+	"github.com/gflydev/core/log"
+	"github.com/gflydev/core/utils"
+	mb "github.com/gflydev/db"
+	"slices"
+	"sort"
+	"time"
+)
+
+// ---------------------------------------------------------------
+// 					Seeder interface.
+// ---------------------------------------------------------------
+
+// ISeeder The interface seeder.
+type ISeeder interface {
+	// Environments get APP_ENV values the seeder runs in. Empty means all environments.
+	Environments() []string
+	// Dependencies get names of seeders which must run before.
+	Dependencies() []string
+	// Run insert or update seed data. It must be idempotent, seeding twice must not duplicate rows.
+	Run(tx *mb.DBModel) error
+}
+
+// Seeder Abstract seeder running in all environments without dependencies.
+type Seeder struct{}
+
+// Environments implement ISeeder.
+func (s Seeder) Environments() []string {
+	return nil
+}
+
+// Dependencies implement ISeeder.
+func (s Seeder) Dependencies() []string {
+	return nil
+}
+
+// NonProduction environments of seeders with demo data, never run in production.
+var NonProduction = []string{constant.EnvLocal, constant.EnvDev, constant.EnvStaging}
+
+// ---------------------------------------------------------------
+// 					Seeder registry.
+// ---------------------------------------------------------------
+
+// seeders pool to store seeders by name.
+var seeders = make(map[string]ISeeder)
+
+// Register Register a new seeder to pool.
+func Register(seeder ISeeder, name string) {
+	seeders[name] = seeder
+}
+
+// Run run seeders of given names, or all registered seeders, after their dependencies.
+// Each seeder runs once in its own transaction. Seeders not allowed in current APP_ENV are skipped.
+//
+//	./artisan db:seed
+//	./artisan db:seed roles
+func Run(names ...string) error {
+	ordered, err := order(seeders, names)
+	if err != nil {
+		return err
+	}
+
+	env := utils.Getenv("APP_ENV", constant.EnvLocal)
+
+	for _, name := range ordered {
+		seeder := seeders[name]
+
+		if environments := seeder.Environments(); len(environments) > 0 && !slices.Contains(environments, env) {
+			log.Infof("Skip seeder %s in environment %s", name, env)
+
+			continue
+		}
+
+		start := time.Now()
+		if err = repository.Transaction(seeder.Run); err != nil {
+			return fmt.Errorf("seeder %s: %w", name, err)
+		}
+		log.Infof("Seeded %s (%v)", name, time.Since(start))
+	}
+
+	return nil
+}
+
+// order get names of seeders to run, each one once and after its dependencies. No names means all
+// seeders of the pool, by name.
+func order(pool map[string]ISeeder, names []string) ([]string, error) {
+	if len(names) == 0 {
+		for name := range pool {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+	}
+
+	var ordered []string
+	done := make(map[string]bool)
+
+	var visit func(name string, path []string) error
+	visit = func(name string, path []string) error {
+		if done[name] {
+			return nil
+		}
+		if slices.Contains(path, name) {
+			return fmt.Errorf("seeders have a dependency cycle %v", append(path, name))
+		}
+
+		seeder, ok := pool[name]
+		if !ok {
+			return fmt.Errorf("unknown seeder %q", name)
+		}
+
+		for _, dependency := range seeder.Dependencies() {
+			if err := visit(dependency, append(path, name)); err != nil {
+				return err
+			}
+		}
+		done[name] = true
+		ordered = append(ordered, name)
+
+		return nil
+	}
+
+	for _, name := range names {
+		if err := visit(name, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	return ordered, nil
+}
@@ -0,0 +1,88 @@
+package seed
+
+import (
+	mb "github.com/gflydev/db"
+	"slices"
+	"testing"
+)
+
+// fakeSeeder a seeder with given dependencies.
+type fakeSeeder struct {
+	Seeder
+	dependencies []string
+}
+
+// Dependencies implement ISeeder.
+func (s fakeSeeder) Dependencies() []string {
+	return s.dependencies
+}
+
+// Run implement ISeeder.
+func (s fakeSeeder) Run(_ *mb.DBModel) error {
+	return nil
+}
+
+// newPool create a seeder pool from dependencies of each seeder name.
+func newPool(dependencies map[string][]string) map[string]ISeeder {
+	pool := make(map[string]ISeeder)
+	for name, names := range dependencies {
+		pool[name] = fakeSeeder{dependencies: names}
+	}
+
+	return pool
+}
+
+func TestOrder(t *testing.T) {
+	pool := newPool(map[string][]string{
+		"roles":       nil,
+		"permissions": {"roles"},
+		"admin-user":  {"roles", "permissions"},
+		"demo-users":  {"roles"},
+		"addresses":   {"demo-users", "admin-user"},
+	})
+
+	tests := []struct {
+		name  string
+		names []string
+		want  []string
+	}{
+		{"all seeders by name after dependencies", nil,
+			[]string{"roles", "demo-users", "permissions", "admin-user", "addresses"}},
+		{"one seeder with its dependencies", []string{"admin-user"},
+			[]string{"roles", "permissions", "admin-user"}},
+		{"shared dependency runs once", []string{"demo-users", "permissions"},
+			[]string{"roles", "demo-users", "permissions"}},
+		{"repeated name runs once", []string{"roles", "roles"},
+			[]string{"roles"}},
+	}
+
+	for _, tt := range tests {
+		got, err := order(pool, tt.names)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tt.name, err)
+		}
+
+		if !slices.Equal(got, tt.want) {
+			t.Fatalf("%s: expected %v, got %v", tt.name, tt.want, got)
+		}
+	}
+}
+
+func TestOrderErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		pool  map[string]ISeeder
+		names []string
+	}{
+		{"unknown seeder", newPool(map[string][]string{"roles": nil}), []string{"users"}},
+		{"unknown dependency", newPool(map[string][]string{"users": {"roles"}}), nil},
+		{"dependency cycle", newPool(map[string][]string{"a": {"b"}, "b": {"c"}, "c": {"a"}}), nil},
+		{"self dependency", newPool(map[string][]string{"a": {"a"}}), nil},
+	}
+
+	for _, tt := range tests {
+		if _, err := order(tt.pool, tt.names); err == nil {
+			t.Fatalf("%s: expected an error", tt.name)
+		}
+	}
+}
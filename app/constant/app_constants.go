@@ -4,3 +4,11 @@ const (
 	// AppName Application name
 	AppName string = "gFly"
 )
+
+// Application environments of env APP_ENV.
+const (
+	EnvLocal   = "local"
+	EnvDev     = "dev"
+	EnvStaging = "stag"
+	EnvProd    = "prod"
+)
@@ -0,0 +1,33 @@
+package constant
+
+// Error codes returned in the `code` field of API error responses.
+const (
+	// ErrCodeBadRequest Request payload is missing or malformed.
+	ErrCodeBadRequest = "BAD_REQUEST"
+	// ErrCodeValidation Request data does not pass validation rules.
+	ErrCodeValidation = "VALIDATION_FAILED"
+	// ErrCodeInvalidCredentials Email or password does not match.
+	ErrCodeInvalidCredentials = "INVALID_CREDENTIALS"
+	// ErrCodeUserBlocked User account was blocked.
+	ErrCodeUserBlocked = "USER_BLOCKED"
+	// ErrCodeUserPending User account is waiting for activation.
+	ErrCodeUserPending = "USER_PENDING"
+	// ErrCodeEmailNotVerified User email is not verified yet.
+	ErrCodeEmailNotVerified = "EMAIL_NOT_VERIFIED"
+	// ErrCodeUnauthorized Request requires an authenticated user.
+	ErrCodeUnauthorized = "UNAUTHORIZED"
+	// ErrCodeForbidden Authenticated user does not have enough privileges.
+	ErrCodeForbidden = "FORBIDDEN"
+	// ErrCodeCSRFMismatch CSRF token of a web form is missing or invalid.
+	ErrCodeCSRFMismatch = "CSRF_TOKEN_MISMATCH"
+	// ErrCodeInvalidToken Access or refresh token is invalid, expired or revoked.
+	ErrCodeInvalidToken = "INVALID_TOKEN"
+	// ErrCodeNotFound Requested resource does not exist.
+	ErrCodeNotFound = "NOT_FOUND"
+	// ErrCodeConflict Resource conflicts with an existing one.
+	ErrCodeConflict = "CONFLICT"
+	// ErrCodeTooManyRequests Client sent too many requests in a period.
+	ErrCodeTooManyRequests = "TOO_MANY_REQUESTS"
+	// ErrCodeInternal Unexpected server error.
+	ErrCodeInternal = "INTERNAL_ERROR"
+)
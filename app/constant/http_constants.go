@@ -0,0 +1,35 @@
+package constant
+
+// Keys to share data between middlewares, validators and handlers via `core.Ctx`.
+const (
+	// Request Parsed request DTO.
+	Request = "__request__"
+	// User Authenticated user `models.User`.
+	User = "__user__"
+	// UserRoles Roles `[]models.Role` of authenticated user.
+	UserRoles = "__user_roles__"
+	// RequestID ID of current request.
+	RequestID = "__request_id__"
+	// CSRFToken CSRF token of current session.
+	CSRFToken = "__csrf_token__"
+	// TraceContext `context.Context` holding the trace span of current request.
+	TraceContext = "__trace_context__"
+)
+
+// Session keys
+const (
+	// SessionUserID ID of user who signed in a web page.
+	SessionUserID = "user_id"
+	// SessionCSRFToken CSRF token protecting web forms.
+	SessionCSRFToken = "csrf_token"
+)
+
+// HTTP headers
+const (
+	// HeaderRequestID header carrying ID of a request.
+	HeaderRequestID = "X-Request-ID"
+	// HeaderCSRFToken header carrying CSRF token of AJAX requests from web pages.
+	HeaderCSRFToken = "X-CSRF-Token"
+	// HeaderAPIKey header carrying API key of clients.
+	HeaderAPIKey = "X-API-Key"
+)
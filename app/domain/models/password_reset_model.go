@@ -0,0 +1,34 @@
+package models
+
+import (
+	"database/sql"
+	mb "github.com/gflydev/db"
+	"time"
+)
+
+// ====================================================================
+// ============================ Data Types ============================
+// ====================================================================
+
+// N/A
+
+// ====================================================================
+// ============================== Table ===============================
+// ====================================================================
+
+// TablePasswordReset Table name
+const TablePasswordReset = "password_resets"
+
+// PasswordReset struct to describe a password reset request. Only the hash of the token is stored.
+type PasswordReset struct {
+	// Table meta data
+	MetaData mb.MetaData `db:"-" model:"table:password_resets"`
+
+	// Table fields
+	ID        int          `db:"id" model:"name:id; type:serial,primary"`
+	UserID    int          `db:"user_id" model:"name:user_id; type:int"`
+	Token     string       `db:"token" model:"name:token"`
+	ExpiresAt time.Time    `db:"expires_at" model:"name:expires_at"`
+	UsedAt    sql.NullTime `db:"used_at" model:"name:used_at"`
+	CreatedAt time.Time    `db:"created_at" model:"name:created_at"`
+}
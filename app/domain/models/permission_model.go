@@ -0,0 +1,46 @@
+package models
+
+import (
+	"database/sql"
+	mb "github.com/gflydev/db"
+	"time"
+)
+
+// ====================================================================
+// ============================ Data Types ============================
+// ====================================================================
+
+// Permission slugs match with database.
+const (
+	PermissionUsersList     = "users.list"
+	PermissionUsersView     = "users.view"
+	PermissionUsersCreate   = "users.create"
+	PermissionUsersUpdate   = "users.update"
+	PermissionUsersDelete   = "users.delete"
+	PermissionUsersBlock    = "users.block"
+	PermissionUsersPassword = "users.password"
+	PermissionRolesAssign   = "roles.assign"
+	PermissionProfileView   = "profile.view"
+	PermissionProfileEdit   = "profile.update"
+	PermissionSystemManage  = "system.manage"
+)
+
+// ====================================================================
+// ============================== Table ===============================
+// ====================================================================
+
+// TablePermission Table name
+const TablePermission = "permissions"
+
+// Permission struct to describe a permission object.
+type Permission struct {
+	// Table meta data
+	MetaData mb.MetaData `db:"-" model:"table:permissions"`
+
+	// Table fields
+	ID        int          `db:"id" model:"name:id; type:serial,primary"`
+	Name      string       `db:"name" model:"name:name"`
+	Slug      string       `db:"slug" model:"name:slug"`
+	CreatedAt time.Time    `db:"created_at" model:"name:created_at"`
+	UpdatedAt sql.NullTime `db:"updated_at" model:"name:updated_at"`
+}
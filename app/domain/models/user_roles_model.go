@@ -24,7 +24,7 @@ type UserRole struct {
 	MetaData mb.MetaData `db:"-" model:"table:user_roles"`
 
 	// Table fields
-	ID        int       `db:"id" model:"name:id; type:int,primary"`
+	ID        int       `db:"id" model:"name:id; type:serial,primary"`
 	RoleID    int       `db:"role_id" model:"name:role_id; type:int"`
 	UserID    int       `db:"user_id" model:"name:user_id; type:int"`
 	CreatedAt time.Time `db:"created_at" model:"name:created_at"`
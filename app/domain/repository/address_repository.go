@@ -0,0 +1,210 @@
+package repository
+
+import (
+	"database/sql"
+	"gfly/app/domain/models"
+	"github.com/gflydev/core/log"
+	"time"
+
+	mb "github.com/gflydev/db"          // Model builder
+	qb "github.com/jivegroup/fluentsql" // Query builder
+)
+
+// ====================================================================
+// =================== Address Repository Interface ===================
+// ====================================================================
+
+// IAddressRepository an interface for any repository implementation.
+type IAddressRepository interface {
+	GetAddressesByUserID(userID int) []models.Address
+	GetAddressByID(userID, addressID int) *models.Address
+	CreateAddress(address *models.Address) error
+	UpdateAddress(address *models.Address) error
+	DeleteAddress(address *models.Address) error
+}
+
+// ====================================================================
+// =================== Address Repository Implement ===================
+// ====================================================================
+
+// AddressRepository struct for queries from an Address model.
+// The struct is an implementation of interface IAddressRepository
+//
+// Each user has exactly one default address per type. Writing queries run in a transaction
+// which locks the owner row, so concurrent requests can not break the rule.
+type AddressRepository struct {
+}
+
+// GetAddressesByUserID query for getting addresses of given user ID.
+func (q *AddressRepository) GetAddressesByUserID(userID int) []models.Address {
+	// Define address variable.
+	var addresses []models.Address
+
+	builder := mb.Instance().Where("user_id", qb.Eq, userID)
+	ScopeTrashed(builder, models.TableAddress, WithoutTrashed)
+
+	_, err := builder.OrderBy("type", qb.Asc).
+		OrderBy("is_default", qb.Desc).
+		OrderBy("id", qb.Asc).
+		Find(&addresses)
+	if err != nil {
+		log.Error(err)
+	}
+
+	// Return query result.
+	return addresses
+}
+
+// GetAddressByID query for getting address by given ID of given user ID. Return nil if not found.
+func (q *AddressRepository) GetAddressByID(userID, addressID int) *models.Address {
+	address, err := mb.GetModel[models.Address](
+		qb.Condition{Field: "id", Opt: qb.Eq, Value: addressID},
+		qb.Condition{Field: "user_id", Opt: qb.Eq, Value: userID},
+		qb.Condition{Field: ColumnDeletedAt, Opt: qb.Null},
+	)
+	if err != nil || address == nil || address.ID == 0 {
+		return nil
+	}
+
+	return address
+}
+
+// CreateAddress query for creating a new address. The first address of a type becomes default.
+func (q *AddressRepository) CreateAddress(address *models.Address) error {
+	return Transaction(func(tx *mb.DBModel) error {
+		if err := lockRow(tx, models.TableUser, address.UserID); err != nil {
+			return err
+		}
+
+		if err := keepSingleDefault(tx, address); err != nil {
+			return err
+		}
+
+		return tx.Create(address)
+	})
+}
+
+// UpdateAddress query for updating an existing address. A default address can only lose its flag
+// when another address of the same type is set as default.
+func (q *AddressRepository) UpdateAddress(address *models.Address) error {
+	return Transaction(func(tx *mb.DBModel) error {
+		if err := lockRow(tx, models.TableUser, address.UserID); err != nil {
+			return err
+		}
+
+		var current models.Address
+		if err := tx.Where("id", qb.Eq, address.ID).First(&current); err != nil {
+			return err
+		}
+
+		if err := keepSingleDefault(tx, address); err != nil {
+			return err
+		}
+
+		if err := tx.Update(address); err != nil {
+			return err
+		}
+
+		// Type changed, previous type needs another default address
+		if current.Type != address.Type && current.IsDefault {
+			return promoteDefault(tx, address.UserID, current.Type)
+		}
+
+		return nil
+	})
+}
+
+// DeleteAddress query for soft deleting an existing address. Another address of the same type
+// becomes default when the deleted one was default.
+func (q *AddressRepository) DeleteAddress(address *models.Address) error {
+	return Transaction(func(tx *mb.DBModel) error {
+		if err := lockRow(tx, models.TableUser, address.UserID); err != nil {
+			return err
+		}
+
+		if err := SoftDelete[models.Address](address.ID, tx); err != nil {
+			return err
+		}
+
+		address.DeletedAt = sql.NullTime{Time: time.Now(), Valid: true}
+
+		if address.IsDefault {
+			address.IsDefault = false
+
+			return promoteDefault(tx, address.UserID, address.Type)
+		}
+
+		return nil
+	})
+}
+
+// ====================================================================
+// ============================== Helpers =============================
+// ====================================================================
+
+// keepSingleDefault un-mark other default addresses when given address is default.
+// Otherwise, make given address default if its type has no default address yet.
+func keepSingleDefault(tx *mb.DBModel, address *models.Address) error {
+	if !address.IsDefault {
+		sqlStr, args, _ := defaultsQuery(address.UserID, address.Type).
+			Select("COUNT(*) AS total").
+			Where("id", qb.NotEq, address.ID).
+			Sql()
+
+		var total int
+		if err := tx.Raw(sqlStr, args...).First(&total); err != nil {
+			return err
+		}
+
+		address.IsDefault = total == 0
+
+		return nil
+	}
+
+	sqlStr, args, _ := qb.UpdateInstance().
+		Update(models.TableAddress).
+		Set("is_default", false).
+		Where("user_id", qb.Eq, address.UserID).
+		Where("type", qb.Eq, address.Type).
+		Where("is_default", qb.Eq, true).
+		Where("id", qb.NotEq, address.ID).
+		Sql()
+
+	return tx.Raw(sqlStr, args...).Update(&models.Address{})
+}
+
+// promoteDefault make the latest address of given type default.
+func promoteDefault(tx *mb.DBModel, userID int, addressType string) error {
+	var addresses []models.Address
+
+	builder := tx.Where("user_id", qb.Eq, userID).
+		Where("type", qb.Eq, addressType)
+	ScopeTrashed(builder, models.TableAddress, WithoutTrashed)
+
+	if _, err := builder.OrderBy("id", qb.Desc).Limit(1, 0).Find(&addresses); err != nil {
+		return err
+	}
+
+	// No address of the type left
+	if len(addresses) == 0 {
+		return nil
+	}
+
+	sqlStr, args, _ := qb.UpdateInstance().
+		Update(models.TableAddress).
+		Set("is_default", true).
+		Where("id", qb.Eq, addresses[0].ID).
+		Sql()
+
+	return tx.Raw(sqlStr, args...).Update(&models.Address{})
+}
+
+// defaultsQuery query of default addresses of given user ID and type.
+func defaultsQuery(userID int, addressType string) *qb.QueryBuilder {
+	return qb.QueryInstance().
+		From(models.TableAddress).
+		Where("user_id", qb.Eq, userID).
+		Where("type", qb.Eq, addressType).
+		Where("is_default", qb.Eq, true).
+		Where(ColumnDeletedAt, qb.Null, nil)
+}
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"database/sql"
+	"sync"
+
+	mb "github.com/gflydev/db" // Model builder
+	"github.com/jmoiron/sqlx"
+)
+
+// ====================================================================
+// ============================ Connection ============================
+// ====================================================================
+
+var (
+	connection     *sqlx.DB
+	connectionLock sync.RWMutex
+)
+
+// Driver wrap a database driver to keep the connection pool loaded by the model builder,
+// then pool stats can be collected. Pool size follows env DB_MAX_CONNECTION, DB_MAX_IDLE_CONNECTION...
+//
+//	mb.Register(repository.Driver(dbPSQL.New()))
+//	mb.Load()
+func Driver(driver mb.IDatabase) mb.IDatabase {
+	return &trackedDriver{driver}
+}
+
+// trackedDriver a database driver keeping its loaded connection pool.
+type trackedDriver struct {
+	mb.IDatabase
+}
+
+// Load implement mb.IDatabase.
+func (d *trackedDriver) Load() (*sqlx.DB, error) {
+	db, err := d.IDatabase.Load()
+	if err == nil {
+		connectionLock.Lock()
+		connection = db
+		connectionLock.Unlock()
+	}
+
+	return db, err
+}
+
+// Stats get stats of the database connection pool. Return false when no pool was loaded via Driver.
+func Stats() (sql.DBStats, bool) {
+	connectionLock.RLock()
+	defer connectionLock.RUnlock()
+
+	if connection == nil {
+		return sql.DBStats{}, false
+	}
+
+	return connection.Stats(), true
+}
+
+// Close close the database connection pool loaded via Driver, waiting for running queries.
+func Close() error {
+	connectionLock.Lock()
+	defer connectionLock.Unlock()
+
+	if connection == nil {
+		return nil
+	}
+
+	err := connection.Close()
+	connection = nil
+
+	return err
+}
+
+// DB get the database connection pool loaded via Driver, or nil before loading.
+func DB() *sqlx.DB {
+	connectionLock.RLock()
+	defer connectionLock.RUnlock()
+
+	return connection
+}
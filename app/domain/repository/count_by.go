@@ -0,0 +1,23 @@
+package repository
+
+import (
+	mb "github.com/gflydev/db"          // Model builder
+	qb "github.com/jivegroup/fluentsql" // Query builder
+)
+
+// CountBy count rows of given table having column equal to given value.
+// Table and column must come from code, never from user input.
+func CountBy(table, column string, value any) (int, error) {
+	sqlStr, args, _ := qb.QueryInstance().
+		Select("COUNT(*) AS total").
+		From(table).
+		Where(column, qb.Eq, value).
+		Sql()
+
+	var total int
+	err := perform(func() error {
+		return mb.Instance().Raw(sqlStr, args...).First(&total)
+	})
+
+	return total, err
+}
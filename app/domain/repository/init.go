@@ -7,9 +7,17 @@ package repository
 // Repositories struct for collect all app repositories.
 type Repositories struct {
 	IRoleRepository
+	IPermissionRepository
+	IUserRepository
+	IAddressRepository
+	IPasswordResetRepository
 }
 
 // Pool a repository pool to store all
 var Pool = &Repositories{
 	&RoleRepository{},
+	&PermissionRepository{},
+	&UserRepository{},
+	&AddressRepository{},
+	&PasswordResetRepository{},
 }
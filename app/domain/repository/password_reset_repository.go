@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"database/sql"
+	"gfly/app/domain/models"
+	"gfly/app/errors"
+	"time"
+
+	mb "github.com/gflydev/db"          // Model builder
+	qb "github.com/jivegroup/fluentsql" // Query builder
+)
+
+// ====================================================================
+// ================ Password Reset Repository Interface ===============
+// ====================================================================
+
+// IPasswordResetRepository an interface for any repository implementation.
+type IPasswordResetRepository interface {
+	GetPasswordResetByToken(token string) *models.PasswordReset
+	CreatePasswordReset(reset *models.PasswordReset) error
+	ConsumePasswordReset(reset *models.PasswordReset, password string) error
+}
+
+// ====================================================================
+// ================ Password Reset Repository Implement ===============
+// ====================================================================
+
+// ErrRecordConsumed a single-use record was already consumed.
+var ErrRecordConsumed = errors.Conflict("Record was already consumed")
+
+// PasswordResetRepository struct for queries from a PasswordReset model.
+// The struct is an implementation of interface IPasswordResetRepository
+type PasswordResetRepository struct {
+}
+
+// GetPasswordResetByToken query for getting password reset by given hashed token. Return nil if not found.
+func (q *PasswordResetRepository) GetPasswordResetByToken(token string) *models.PasswordReset {
+	reset, err := mb.GetModelBy[models.PasswordReset]("token", token)
+	if err != nil || reset == nil || reset.ID == 0 {
+		return nil
+	}
+
+	return reset
+}
+
+// CreatePasswordReset query for creating a new password reset. Unused resets of the same user are invalidated.
+func (q *PasswordResetRepository) CreatePasswordReset(reset *models.PasswordReset) error {
+	return Transaction(func(tx *mb.DBModel) error {
+		sqlStr, args, _ := qb.UpdateInstance().
+			Update(models.TablePasswordReset).
+			Set("used_at", time.Now()).
+			Where("user_id", qb.Eq, reset.UserID).
+			Where("used_at", qb.Null, nil).
+			Sql()
+
+		if err := tx.Raw(sqlStr, args...).Update(&models.PasswordReset{}); err != nil {
+			return err
+		}
+
+		return tx.Create(reset)
+	})
+}
+
+// ConsumePasswordReset query for marking given password reset as used and setting new hashed password
+// of its user in one transaction. Return ErrRecordConsumed if the reset was used by another request.
+func (q *PasswordResetRepository) ConsumePasswordReset(reset *models.PasswordReset, password string) error {
+	return Transaction(func(tx *mb.DBModel) error {
+		var current models.PasswordReset
+
+		sqlStr, args, _ := qb.QueryInstance().
+			Select("*").
+			From(models.TablePasswordReset).
+			Where("id", qb.Eq, reset.ID).
+			Sql()
+
+		if err := tx.Raw(sqlStr+" FOR UPDATE", args...).First(&current); err != nil {
+			return err
+		}
+
+		if current.UsedAt.Valid {
+			return ErrRecordConsumed
+		}
+
+		now := time.Now()
+
+		sqlStr, args, _ = qb.UpdateInstance().
+			Update(models.TablePasswordReset).
+			Set("used_at", now).
+			Where("id", qb.Eq, reset.ID).
+			Sql()
+
+		if err := tx.Raw(sqlStr, args...).Update(&models.PasswordReset{}); err != nil {
+			return err
+		}
+
+		sqlStr, args, _ = qb.UpdateInstance().
+			Update(models.TableUser).
+			Set("password", password).
+			Set("updated_at", now).
+			Where("id", qb.Eq, reset.UserID).
+			Sql()
+
+		if err := tx.Raw(sqlStr, args...).Update(&models.User{}); err != nil {
+			return err
+		}
+
+		reset.UsedAt = sql.NullTime{Time: now, Valid: true}
+
+		return nil
+	})
+}
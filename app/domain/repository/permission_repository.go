@@ -0,0 +1,159 @@
+package repository
+
+import (
+	"encoding/json"
+	"fmt"
+	"gfly/app/domain/models"
+	"gfly/app/errors"
+	"github.com/gflydev/cache"
+	"github.com/gflydev/core/log"
+	"time"
+
+	mb "github.com/gflydev/db"          // Model builder
+	qb "github.com/jivegroup/fluentsql" // Query builder
+)
+
+// ====================================================================
+// ================= Permission Repository Interface ==================
+// ====================================================================
+
+// IPermissionRepository an interface for any repository implementation.
+type IPermissionRepository interface {
+	GetPermissionsByUserID(userID int) []models.Permission
+	GetPermissionsByRoleID(roleID int) []models.Permission
+	AddPermissionForRole(roleSlug, permissionSlug string) error
+}
+
+// ====================================================================
+// ================= Permission Repository Implement ==================
+// ====================================================================
+
+// ErrPermissionNotFound permission slug does not exist.
+var ErrPermissionNotFound = errors.NotFound("Permission not found")
+
+// userPermissionsCacheKey cache key to keep permissions of a user.
+const userPermissionsCacheKey = "permissions:user:%d"
+
+// userPermissionsCacheTTL lifetime of cached permissions.
+const userPermissionsCacheTTL = time.Hour
+
+// PermissionRepository struct for queries from a Permission model.
+// The struct is an implementation of interface IPermissionRepository
+type PermissionRepository struct {
+}
+
+// GetPermissionsByUserID query for getting permissions of all roles of given user ID.
+// Permissions are cached per user in Redis.
+func (q *PermissionRepository) GetPermissionsByUserID(userID int) []models.Permission {
+	// Define permission variable.
+	var permissions []models.Permission
+
+	// Get from cache
+	if cached, err := cache.Get(fmt.Sprintf(userPermissionsCacheKey, userID)); err == nil && cached != nil {
+		if err = json.Unmarshal([]byte(fmt.Sprint(cached)), &permissions); err == nil {
+			return permissions
+		}
+	}
+
+	_, err := mb.Instance().Select("DISTINCT "+models.TablePermission+".*").
+		Join(qb.InnerJoin, models.TableRolePermission, qb.Condition{
+			Field: models.TablePermission + ".id",
+			Opt:   qb.Eq,
+			Value: qb.ValueField(models.TableRolePermission + ".permission_id"),
+		}).
+		Join(qb.InnerJoin, models.TableUserRole, qb.Condition{
+			Field: models.TableUserRole + ".role_id",
+			Opt:   qb.Eq,
+			Value: qb.ValueField(models.TableRolePermission + ".role_id"),
+		}).
+		Where(models.TableUserRole+".user_id", qb.Eq, userID).
+		OrderBy("slug", qb.Asc).
+		Find(&permissions)
+
+	if err != nil {
+		log.Error(err)
+
+		return permissions
+	}
+
+	// Keep in cache
+	if data, e := json.Marshal(permissions); e == nil {
+		_ = cache.Set(fmt.Sprintf(userPermissionsCacheKey, userID), data, userPermissionsCacheTTL)
+	}
+
+	// Return query result.
+	return permissions
+}
+
+// GetPermissionsByRoleID query for getting permissions by given role ID.
+func (q *PermissionRepository) GetPermissionsByRoleID(roleID int) []models.Permission {
+	// Define permission variable.
+	var permissions []models.Permission
+
+	_, err := mb.Instance().Select(models.TablePermission+".*").
+		Join(qb.InnerJoin, models.TableRolePermission, qb.Condition{
+			Field: models.TablePermission + ".id",
+			Opt:   qb.Eq,
+			Value: qb.ValueField(models.TableRolePermission + ".permission_id"),
+		}).
+		Where(models.TableRolePermission+".role_id", qb.Eq, roleID).
+		OrderBy("slug", qb.Asc).
+		Find(&permissions)
+
+	if err != nil {
+		log.Error(err)
+	}
+
+	// Return query result.
+	return permissions
+}
+
+// AddPermissionForRole query for granting permission to given role.
+func (q *PermissionRepository) AddPermissionForRole(roleSlug, permissionSlug string) error {
+	// Get role by slug
+	role, err := mb.GetModelBy[models.Role]("slug", roleSlug)
+	if err != nil || role == nil {
+		log.Error(err)
+
+		return ErrRoleNotFound
+	}
+
+	// Get permission by slug
+	permission, err := mb.GetModelBy[models.Permission]("slug", permissionSlug)
+	if err != nil || permission == nil {
+		log.Error(err)
+
+		return ErrPermissionNotFound
+	}
+
+	// Create new role permission
+	rolePermission := models.RolePermission{
+		RoleID:       role.ID,
+		PermissionID: permission.ID,
+		CreatedAt:    time.Now(),
+	}
+
+	if err = mb.CreateModel(&rolePermission); err != nil {
+		return err
+	}
+
+	// Invalidate cached permissions of all users having the role
+	var userRoles []models.UserRole
+	_, err = mb.Instance().Where("role_id", qb.Eq, role.ID).Find(&userRoles)
+	if err != nil {
+		log.Error(err)
+	}
+
+	for _, userRole := range userRoles {
+		ForgetUserPermissions(userRole.UserID)
+	}
+
+	return nil
+}
+
+// ForgetUserPermissions remove cached permissions of given user ID.
+func ForgetUserPermissions(userID int) {
+	if err := cache.Del(fmt.Sprintf(userPermissionsCacheKey, userID)); err != nil {
+		log.Warnf("Can not forget permissions of user %d", userID)
+	}
+}
@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+	"github.com/gflydev/core/errors"
+)
+
+// Ping check the database connection by a trivial query. It gives up when `ctx` is done.
+func Ping(ctx context.Context) error {
+	db := DB()
+	if db == nil {
+		return errors.New("database connection is not loaded")
+	}
+
+	var one int
+
+	return db.QueryRowContext(ctx, "SELECT 1").Scan(&one)
+}
@@ -1,8 +1,11 @@
 package repository
 
 import (
+	"encoding/json"
+	"fmt"
 	"gfly/app/domain/models"
-	"github.com/gflydev/core/errors"
+	"gfly/app/errors"
+	"github.com/gflydev/cache"
 	"github.com/gflydev/core/log"
 	"time"
 
@@ -17,6 +20,7 @@ import (
 // IRoleRepository an interface for any repository implementation.
 type IRoleRepository interface {
 	GetRolesByUserID(userID int) []models.Role
+	GetRolesByUserIDs(userIDs []int) map[int][]models.Role
 	AddRoleForUserID(userID int, slug string) error
 }
 
@@ -24,16 +28,33 @@ type IRoleRepository interface {
 // ==================== Role Repository Implement =====================
 // ====================================================================
 
+// ErrRoleNotFound role slug does not exist.
+var ErrRoleNotFound = errors.NotFound("Role not found")
+
+// userRolesCacheKey cache key to keep roles of a user.
+const userRolesCacheKey = "roles:user:%d"
+
+// userRolesCacheTTL lifetime of cached roles.
+const userRolesCacheTTL = time.Hour
+
 // RoleRepository struct for queries from a Role model.
 // The struct is an implementation of interface IRoleRepository
 type RoleRepository struct {
 }
 
 // GetRolesByUserID query for getting roles by given user ID.
+// Roles are cached per user in Redis.
 func (q *RoleRepository) GetRolesByUserID(userID int) []models.Role {
 	// Define role variable.
 	var roles []models.Role
 
+	// Get from cache
+	if cached, err := cache.Get(fmt.Sprintf(userRolesCacheKey, userID)); err == nil && cached != nil {
+		if err = json.Unmarshal([]byte(fmt.Sprint(cached)), &roles); err == nil {
+			return roles
+		}
+	}
+
 	_, err := mb.Instance().Select(models.TableRole+".*").
 		Join(qb.InnerJoin, models.TableUserRole, qb.Condition{
 			Field: models.TableRole + ".id",
@@ -46,33 +67,103 @@ func (q *RoleRepository) GetRolesByUserID(userID int) []models.Role {
 
 	if err != nil {
 		log.Error(err)
+
+		return roles
+	}
+
+	// Keep in cache
+	if data, e := json.Marshal(roles); e == nil {
+		_ = cache.Set(fmt.Sprintf(userRolesCacheKey, userID), data, userRolesCacheTTL)
 	}
 
 	// Return query result.
 	return roles
 }
 
+// GetRolesByUserIDs query for getting roles of many users at once, keyed by user ID.
+// Used by listings to avoid one query per user.
+func (q *RoleRepository) GetRolesByUserIDs(userIDs []int) map[int][]models.Role {
+	rolesByUser := make(map[int][]models.Role, len(userIDs))
+	if len(userIDs) == 0 {
+		return rolesByUser
+	}
+
+	var userRoles []models.UserRole
+	if _, err := mb.Instance().Where("user_id", qb.In, userIDs).Find(&userRoles); err != nil {
+		log.Error(err)
+
+		return rolesByUser
+	}
+	if len(userRoles) == 0 {
+		return rolesByUser
+	}
+
+	roleIDs := make([]int, 0, len(userRoles))
+	for _, userRole := range userRoles {
+		roleIDs = append(roleIDs, userRole.RoleID)
+	}
+
+	var roles []models.Role
+	if _, err := mb.Instance().Where("id", qb.In, roleIDs).OrderBy("name", qb.Asc).Find(&roles); err != nil {
+		log.Error(err)
+
+		return rolesByUser
+	}
+
+	// Keep order by name for each user
+	for _, role := range roles {
+		for _, userRole := range userRoles {
+			if userRole.RoleID == role.ID {
+				rolesByUser[userRole.UserID] = append(rolesByUser[userRole.UserID], role)
+			}
+		}
+	}
+
+	return rolesByUser
+}
+
 // AddRoleForUserID query for adding role for given user ID.
 func (q *RoleRepository) AddRoleForUserID(userID int, slug string) error {
+	if err := Transaction(func(tx *mb.DBModel) error {
+		return addRoleForUserID(tx, userID, slug)
+	}); err != nil {
+		return err
+	}
+
+	// Invalidate cached roles and permissions
+	ForgetUserRoles(userID)
+	ForgetUserPermissions(userID)
+
+	return nil
+}
+
+// addRoleForUserID add role for given user ID in given transaction.
+func addRoleForUserID(tx *mb.DBModel, userID int, slug string) error {
 	// Get role by slug
 	role, err := mb.GetModel[models.Role](qb.Condition{
 		Field: "slug",
 		Opt:   qb.Eq,
 		Value: slug,
 	})
-	if err != nil || role == nil {
+	if err != nil || role == nil || role.ID == 0 {
 		log.Error(err)
 
-		return errors.New("Role not found")
+		return ErrRoleNotFound
 	}
 
-	// Create new user
+	// Create new user role. ID is generated by database.
 	userRole := models.UserRole{
-		ID:        userID,
 		RoleID:    role.ID,
 		UserID:    userID,
 		CreatedAt: time.Now(),
 	}
 
-	return mb.CreateModel(&userRole)
+	return tx.Create(&userRole)
+}
+
+// ForgetUserRoles remove cached roles of given user ID.
+func ForgetUserRoles(userID int) {
+	if err := cache.Del(fmt.Sprintf(userRolesCacheKey, userID)); err != nil {
+		log.Warnf("Can not forget roles of user %d", userID)
+	}
 }
@@ -0,0 +1,181 @@
+package repository
+
+import (
+	"github.com/gflydev/core/errors"
+	"github.com/gflydev/core/log"
+	"github.com/gflydev/core/try"
+	"time"
+
+	mb "github.com/gflydev/db"          // Model builder
+	qb "github.com/jivegroup/fluentsql" // Query builder
+)
+
+// ====================================================================
+// ======================== Soft Delete Scope =========================
+// ====================================================================
+
+// ColumnDeletedAt column to mark a soft deleted row.
+const ColumnDeletedAt = "deleted_at"
+
+// Trashed scope of soft deleted rows in a query.
+type Trashed int
+
+const (
+	// WithoutTrashed exclude soft deleted rows (default).
+	WithoutTrashed Trashed = iota
+	// WithTrashed include soft deleted rows.
+	WithTrashed
+	// OnlyTrashed only soft deleted rows.
+	OnlyTrashed
+)
+
+// ScopeTrashed apply soft delete scope on a model builder query of given table.
+//
+//	builder := mb.Instance().Select(models.TableUser + ".*")
+//	ScopeTrashed(builder, models.TableUser, WithoutTrashed)
+func ScopeTrashed(builder *mb.DBModel, table string, trashed Trashed) *mb.DBModel {
+	for _, condition := range trashedConditions(table+"."+ColumnDeletedAt, trashed) {
+		builder.Where(condition.Field, condition.Opt, condition.Value)
+	}
+
+	return builder
+}
+
+// GetModelBy same as mb.GetModelBy but honor soft delete scope. Default scope is WithoutTrashed.
+func GetModelBy[T any](field string, value any, trashed ...Trashed) (*T, error) {
+	conditions := []qb.Condition{{
+		Field: field,
+		Opt:   qb.Eq,
+		Value: value,
+	}}
+
+	return mb.GetModel[T](append(conditions, trashedConditions(ColumnDeletedAt, scopeOf(trashed))...)...)
+}
+
+// SoftDelete mark a row of model T as deleted by given ID. Optional transaction `tx` is used if given.
+func SoftDelete[T any](id int, tx ...*mb.DBModel) error {
+	sqlStr, args, _ := qb.UpdateInstance().
+		Update(tableOf[T]()).
+		Set(ColumnDeletedAt, time.Now()).
+		Where("id", qb.Eq, id).
+		Where(ColumnDeletedAt, qb.Null, nil).
+		Sql()
+
+	return execRaw[T](dbOf(tx), sqlStr, args)
+}
+
+// Restore un-mark a soft deleted row of model T by given ID. Optional transaction `tx` is used if given.
+func Restore[T any](id int, tx ...*mb.DBModel) error {
+	sqlStr, args, _ := qb.UpdateInstance().
+		Update(tableOf[T]()).
+		Set(ColumnDeletedAt, nil).
+		Where("id", qb.Eq, id).
+		Where(ColumnDeletedAt, qb.NotNull, nil).
+		Sql()
+
+	return execRaw[T](dbOf(tx), sqlStr, args)
+}
+
+// PurgeTrashed permanently delete rows of model T which were soft deleted before given time.
+// Return number of purged rows.
+func PurgeTrashed[T any](before time.Time) (int, error) {
+	table := tableOf[T]()
+
+	// Count purged rows
+	var total int
+	countSql, countArgs, _ := qb.QueryInstance().
+		Select("COUNT(*) AS total").
+		From(table).
+		Where(ColumnDeletedAt, qb.NotNull, nil).
+		Where(ColumnDeletedAt, qb.Lesser, before).
+		Sql()
+
+	if err := perform(func() error {
+		return mb.Instance().Raw(countSql, countArgs...).First(&total)
+	}); err != nil || total == 0 {
+		return 0, err
+	}
+
+	sqlStr, args, _ := qb.DeleteInstance().
+		Delete(table).
+		Where(ColumnDeletedAt, qb.NotNull, nil).
+		Where(ColumnDeletedAt, qb.Lesser, before).
+		Sql()
+
+	if err := execRaw[T](mb.Instance(), sqlStr, args); err != nil {
+		return 0, err
+	}
+
+	return total, nil
+}
+
+// ====================================================================
+// ============================== Helpers =============================
+// ====================================================================
+
+// trashedConditions query conditions of given soft delete scope.
+func trashedConditions(column string, trashed Trashed) []qb.Condition {
+	switch trashed {
+	case WithoutTrashed:
+		return []qb.Condition{{Field: column, Opt: qb.Null}}
+	case OnlyTrashed:
+		return []qb.Condition{{Field: column, Opt: qb.NotNull}}
+	}
+
+	return []qb.Condition{}
+}
+
+// scopeOf get scope from optional parameter.
+func scopeOf(trashed []Trashed) Trashed {
+	if len(trashed) > 0 {
+		return trashed[0]
+	}
+
+	return WithoutTrashed
+}
+
+// tableOf get table name of model T.
+func tableOf[T any]() string {
+	var m T
+
+	table, err := mb.ModelData(&m)
+	if err != nil {
+		log.Panic(err)
+	}
+
+	return table.Name
+}
+
+// dbOf get given transaction or a new model builder instance.
+func dbOf(tx []*mb.DBModel) *mb.DBModel {
+	if len(tx) > 0 && tx[0] != nil {
+		return tx[0]
+	}
+
+	return mb.Instance()
+}
+
+// execRaw execute a raw SQL statement.
+func execRaw[T any](db *mb.DBModel, sqlStr string, args []any) error {
+	return perform(func() error {
+		// Raw statement is executed as-is by Update(), the model only satisfies its signature.
+		return db.Raw(sqlStr, args...).Update(new(T))
+	})
+}
+
+// perform run a model builder action and convert its panic to error.
+func perform(action func() error) (err error) {
+	try.Perform(func() {
+		if e := action(); e != nil {
+			try.Throw(e)
+		}
+	}).Catch(func(e try.E) {
+		if ex, ok := e.(error); ok {
+			err = ex
+		} else {
+			err = errors.New("%v", e)
+		}
+	})
+
+	return
+}
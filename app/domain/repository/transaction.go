@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"github.com/gflydev/core/log"
+
+	mb "github.com/gflydev/db"          // Model builder
+	qb "github.com/jivegroup/fluentsql" // Query builder
+)
+
+// ====================================================================
+// =========================== Transaction ============================
+// ====================================================================
+
+// Transaction run given action in a database transaction. The transaction is committed when
+// the action succeeds, otherwise it is rolled back.
+//
+//	err := repository.Transaction(func(tx *mb.DBModel) error {
+//		return tx.Create(&user)
+//	})
+func Transaction(action func(tx *mb.DBModel) error) error {
+	var tx *mb.DBModel
+
+	err := perform(func() error {
+		tx = mb.Instance().Begin()
+
+		return action(tx)
+	})
+
+	if err != nil {
+		if tx != nil {
+			if e := tx.Rollback(); e != nil {
+				log.Error(e)
+			}
+		}
+
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// lockRow lock a row of given table by ID until the transaction ends.
+// It serializes concurrent transactions working on the same row.
+func lockRow(tx *mb.DBModel, table string, id int) error {
+	sqlStr, args, _ := qb.QueryInstance().
+		Select("id").
+		From(table).
+		Where("id", qb.Eq, id).
+		Sql()
+
+	var lockedID int
+
+	return tx.Raw(sqlStr+" FOR UPDATE", args...).First(&lockedID)
+}
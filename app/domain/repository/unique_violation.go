@@ -0,0 +1,17 @@
+package repository
+
+import (
+	stdErrors "errors"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// uniqueViolationCode SQLSTATE of a unique constraint violation in PostgreSQL.
+const uniqueViolationCode = "23505"
+
+// IsUniqueViolation check whether given error comes from a unique constraint, such as a concurrent
+// insert of the same email passing the existence check.
+func IsUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+
+	return stdErrors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode
+}
@@ -0,0 +1,184 @@
+package repository
+
+import (
+	"database/sql"
+	"gfly/app/domain/models"
+	"gfly/app/dto"
+	"github.com/gflydev/core/log"
+	"strings"
+	"time"
+
+	mb "github.com/gflydev/db"          // Model builder
+	qb "github.com/jivegroup/fluentsql" // Query builder
+)
+
+// ====================================================================
+// ==================== User Repository Interface =====================
+// ====================================================================
+
+// IUserRepository an interface for any repository implementation.
+type IUserRepository interface {
+	GetUserByID(userID int, trashed ...Trashed) *models.User
+	GetUserByEmail(email string, trashed ...Trashed) *models.User
+	FindUsers(filter dto.UserFilter) ([]models.User, int)
+	CreateUser(user *models.User, roles ...string) error
+	UpdateUser(user *models.User) error
+	DeleteUser(user *models.User) error
+	RestoreUser(user *models.User) error
+}
+
+// ====================================================================
+// ==================== User Repository Implement =====================
+// ====================================================================
+
+// UserRepository struct for queries from a User model.
+// The struct is an implementation of interface IUserRepository
+type UserRepository struct {
+}
+
+// GetUserByID query for getting user by given ID. Soft deleted users are excluded unless
+// scope WithTrashed or OnlyTrashed is given. Return nil if not found.
+func (q *UserRepository) GetUserByID(userID int, trashed ...Trashed) *models.User {
+	user, err := GetModelBy[models.User]("id", userID, trashed...)
+	if err != nil || user == nil || user.ID == 0 {
+		return nil
+	}
+
+	return user
+}
+
+// GetUserByEmail query for getting user by given email. Soft deleted users are excluded unless
+// scope WithTrashed or OnlyTrashed is given. Return nil if not found.
+func (q *UserRepository) GetUserByEmail(email string, trashed ...Trashed) *models.User {
+	user, err := GetModelBy[models.User]("email", email, trashed...)
+	if err != nil || user == nil || user.ID == 0 {
+		return nil
+	}
+
+	return user
+}
+
+// FindUsers query for listing users by given filter. Return a page of users and total matched users.
+func (q *UserRepository) FindUsers(filter dto.UserFilter) ([]models.User, int) {
+	// Define user variable.
+	var users []models.User
+
+	builder := mb.Instance().Select(models.TableUser + ".*")
+
+	// Soft delete scope
+	switch filter.Trashed {
+	case dto.TrashedWith:
+		ScopeTrashed(builder, models.TableUser, WithTrashed)
+	case dto.TrashedOnly:
+		ScopeTrashed(builder, models.TableUser, OnlyTrashed)
+	default:
+		ScopeTrashed(builder, models.TableUser, WithoutTrashed)
+	}
+
+	// Filter by status
+	if filter.Status != "" {
+		builder.Where(models.TableUser+".status", qb.Eq, filter.Status)
+	}
+
+	// Filter by role
+	if filter.Role != "" {
+		builder.Join(qb.InnerJoin, models.TableUserRole, qb.Condition{
+			Field: models.TableUser + ".id",
+			Opt:   qb.Eq,
+			Value: qb.ValueField(models.TableUserRole + ".user_id"),
+		}).Join(qb.InnerJoin, models.TableRole, qb.Condition{
+			Field: models.TableRole + ".id",
+			Opt:   qb.Eq,
+			Value: qb.ValueField(models.TableUserRole + ".role_id"),
+		}).Where(models.TableRole+".slug", qb.Eq, filter.Role)
+	}
+
+	// Search by email or fullname
+	if filter.Keyword != "" {
+		keyword := "%" + strings.ToLower(filter.Keyword) + "%"
+
+		builder.WhereGroup(func(whereBuilder qb.WhereBuilder) *qb.WhereBuilder {
+			whereBuilder.Where("LOWER("+models.TableUser+".email)", qb.Like, keyword).
+				WhereOr("LOWER("+models.TableUser+".fullname)", qb.Like, keyword)
+
+			return &whereBuilder
+		})
+	}
+
+	// Sort
+	sortField := "created_at"
+	if filter.Sort == "last_access_at" {
+		sortField = filter.Sort
+	}
+	orderDir := qb.Desc
+	if filter.Order == "asc" {
+		orderDir = qb.Asc
+	}
+	builder.OrderBy(models.TableUser+"."+sortField, orderDir)
+
+	// Pagination
+	offset := (filter.Page - 1) * filter.PerPage
+	total, err := builder.Limit(filter.PerPage, offset).Find(&users)
+	if err != nil {
+		log.Error(err)
+	}
+
+	// Return query result.
+	return users, total
+}
+
+// CreateUser query for creating a new user with given role slugs in one transaction.
+// Neither the user nor any role is kept if one of them fails.
+func (q *UserRepository) CreateUser(user *models.User, roles ...string) error {
+	if err := Transaction(func(tx *mb.DBModel) error {
+		if err := tx.Create(user); err != nil {
+			return err
+		}
+
+		for _, role := range roles {
+			if err := addRoleForUserID(tx, user.ID, role); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}); err != nil {
+		user.ID = 0
+
+		return err
+	}
+
+	if len(roles) > 0 {
+		ForgetUserRoles(user.ID)
+		ForgetUserPermissions(user.ID)
+	}
+
+	return nil
+}
+
+// UpdateUser query for updating an existing user.
+func (q *UserRepository) UpdateUser(user *models.User) error {
+	return mb.UpdateModel(user)
+}
+
+// DeleteUser query for soft deleting an existing user.
+func (q *UserRepository) DeleteUser(user *models.User) error {
+	if err := SoftDelete[models.User](user.ID); err != nil {
+		return err
+	}
+
+	user.DeletedAt = sql.NullTime{Time: time.Now(), Valid: true}
+
+	return nil
+}
+
+// RestoreUser query for restoring a soft deleted user.
+func (q *UserRepository) RestoreUser(user *models.User) error {
+	if err := Restore[models.User](user.ID); err != nil {
+		return err
+	}
+
+	user.DeletedAt = sql.NullTime{}
+
+	return nil
+}
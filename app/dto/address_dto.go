@@ -0,0 +1,30 @@
+package dto
+
+// CreateAddress struct to describe create address payload.
+type CreateAddress struct {
+	UserID       int    `json:"-"`
+	Type         string `json:"type" validate:"oneof=address_type" example:"shipping"`
+	IsDefault    bool   `json:"is_default" example:"true"`
+	AddressLine1 string `json:"address_line1" validate:"trim,required,max=150" example:"12 Nguyen Hue"`
+	AddressLine2 string `json:"address_line2" validate:"trim,max=150" example:"Floor 3"`
+	Ward         string `json:"ward" validate:"trim,max=100" example:"Ben Nghe"`
+	District     string `json:"district" validate:"trim,max=100" example:"District 1"`
+	City         string `json:"city" validate:"trim,max=100" example:"Ho Chi Minh"`
+	State        string `json:"state" validate:"trim,max=100" example:""`
+	Country      string `json:"country" validate:"trim,max=100" example:"Vietnam"`
+}
+
+// UpdateAddress struct to describe update address payload. Empty fields are kept unchanged.
+type UpdateAddress struct {
+	ID           int    `json:"-"`
+	UserID       int    `json:"-"`
+	Type         string `json:"type" validate:"oneof=address_type" example:"shipping"`
+	IsDefault    *bool  `json:"is_default" example:"true"`
+	AddressLine1 string `json:"address_line1" validate:"trim,max=150" example:"12 Nguyen Hue"`
+	AddressLine2 string `json:"address_line2" validate:"trim,max=150" example:"Floor 3"`
+	Ward         string `json:"ward" validate:"trim,max=100" example:"Ben Nghe"`
+	District     string `json:"district" validate:"trim,max=100" example:"District 1"`
+	City         string `json:"city" validate:"trim,max=100" example:"Ho Chi Minh"`
+	State        string `json:"state" validate:"trim,max=100" example:""`
+	Country      string `json:"country" validate:"trim,max=100" example:"Vietnam"`
+}
@@ -0,0 +1,50 @@
+package dto
+
+// SignIn struct to describe sign in payload.
+type SignIn struct {
+	Email    string `json:"email" validate:"trim,lower,required,email" example:"admin@gfly.dev"`
+	Password string `json:"password" validate:"required" example:"P@seWor9"`
+}
+
+// RefreshToken struct to describe refresh token payload.
+type RefreshToken struct {
+	Token string `json:"token" validate:"required" example:"d1a5b6c0e0f14f5a8b1f6d3c2a9e7b40"`
+}
+
+// ForgotPassword struct to describe forgot password payload.
+type ForgotPassword struct {
+	RequestID string `json:"-"`
+	Email     string `json:"email" validate:"trim,lower,required,email" example:"admin@gfly.dev"`
+}
+
+// ResetPassword struct to describe reset password payload. Fields `token`, `expires` and `signature`
+// come from the query of the emailed link.
+type ResetPassword struct {
+	Token     string `json:"token" validate:"required" example:"d1a5b6c0e0f14f5a8b1f6d3c2a9e7b40"`
+	Expires   int64  `json:"expires" validate:"required" example:"1715756868"`
+	Signature string `json:"signature" validate:"required" example:"9c1185a5c5e9fc54612808977ee8f548b2258d31"`
+	Password  string `json:"password" validate:"required,password" example:"N3wP@seWor9"`
+}
+
+// VerifyEmail struct to describe email verification parameters from the query of the emailed link.
+type VerifyEmail struct {
+	ID        int    `json:"id" query:"id" validate:"required" example:"1"`
+	Hash      string `json:"hash" query:"hash" validate:"required" example:"a3f5b1c2..."`
+	Expires   int64  `json:"expires" query:"expires" validate:"required" example:"1715756868"`
+	Signature string `json:"signature" query:"signature" validate:"required" example:"9c1185a5c5e9fc54612808977ee8f548b2258d31"`
+}
+
+// ResendVerification struct to describe resend verification email payload.
+type ResendVerification struct {
+	RequestID string `json:"-"`
+	Email     string `json:"email" validate:"trim,lower,required,email" example:"john@gfly.dev"`
+}
+
+// SignUp struct to describe self-registration payload.
+type SignUp struct {
+	RequestID string `json:"-"`
+	Email     string `json:"email" validate:"trim,lower,required,email,max=255,unique=users.email" example:"john@gfly.dev"`
+	Password  string `json:"password" validate:"required,password" example:"P@seWor9"`
+	Fullname  string `json:"fullname" validate:"trim,required,max=255" example:"John Doe"`
+	Phone     string `json:"phone" validate:"trim,phone,max=20" example:"0989831911"`
+}
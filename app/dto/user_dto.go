@@ -0,0 +1,43 @@
+package dto
+
+// Trashed filter values to include soft deleted records.
+const (
+	TrashedWith = "with"
+	TrashedOnly = "only"
+)
+
+// UserFilter struct to describe filter, search, sort and pagination for listing users.
+type UserFilter struct {
+	Keyword string `json:"keyword" query:"keyword" validate:"trim,max=100" example:"john"`
+	Status  string `json:"status" query:"status" validate:"oneof=user_status" example:"active"`
+	Role    string `json:"role" query:"role" validate:"oneof=role" example:"admin"`
+	Sort    string `json:"sort" query:"sort" validate:"oneof=created_at|last_access_at" example:"created_at"`
+	Order   string `json:"order" query:"order" validate:"oneof=asc|desc" example:"desc"`
+	Page    int    `json:"page" query:"page" validate:"min=1" example:"1"`
+	PerPage int    `json:"per_page" query:"per_page" validate:"min=1" example:"20"`
+	Trashed string `json:"trashed" query:"trashed" validate:"oneof=with|only" example:"with"`
+}
+
+// CreateUser struct to describe create user payload.
+type CreateUser struct {
+	RequestID string   `json:"-"`
+	ActorID   int      `json:"-"` // User sending the request, needs `roles.assign` to give roles and `users.block` to block
+	Email     string   `json:"email" validate:"trim,lower,required,email,max=255" example:"john@gfly.dev"`
+	Password  string   `json:"password" validate:"required,password" example:"P@seWor9"`
+	Fullname  string   `json:"fullname" validate:"trim,max=255" example:"John Doe"`
+	Phone     string   `json:"phone" validate:"trim,phone,max=20" example:"0989831911"`
+	Avatar    string   `json:"avatar" validate:"trim,max=255" example:"https://www.gfly.dev/assets/avatar.png"`
+	Status    string   `json:"status" validate:"oneof=user_status" example:"active"`
+	Roles     []string `json:"roles" validate:"exists=roles.slug" example:"member"`
+}
+
+// UpdateUser struct to describe update user payload.
+type UpdateUser struct {
+	ID       int    `json:"-"`
+	ActorID  int    `json:"-"` // User sending the request, needs `users.block` to change status and `users.password` to set password of another user
+	Password string `json:"password" validate:"password" example:"P@seWor9"`
+	Fullname string `json:"fullname" validate:"trim,max=255" example:"John Doe"`
+	Phone    string `json:"phone" validate:"trim,phone,max=20" example:"0989831911"`
+	Avatar   string `json:"avatar" validate:"trim,max=255" example:"https://www.gfly.dev/assets/avatar.png"`
+	Status   string `json:"status" validate:"oneof=user_status" example:"active"`
+}
@@ -0,0 +1,24 @@
+package errors
+
+import (
+	"gfly/app/constant"
+	"github.com/gflydev/core"
+)
+
+// ====================================================================
+// ============================= Catalog ==============================
+// ====================================================================
+
+// Common errors. Domain errors are declared next to their services with constructors of this package.
+//
+//	var ErrUserNotFound = errors.NotFound("User not found")
+var (
+	ErrBadRequest   = BadRequest("Bad request")
+	ErrNotFound     = NotFound("Resource not found")
+	ErrValidation   = Validation("The given data was invalid", nil)
+	ErrConflict     = Conflict("Resource already exists")
+	ErrUnauthorized = Unauthorized("Authentication required")
+	ErrForbidden    = Forbidden("Permission denied")
+	ErrRateLimited  = RateLimited("Too many requests, please try again later")
+	ErrInternal     = New(core.StatusInternalServerError, constant.ErrCodeInternal, "Internal server error")
+)
@@ -0,0 +1,139 @@
+package errors
+
+import (
+	stdErrors "errors"
+	"fmt"
+	"gfly/app/constant"
+	"github.com/gflydev/core"
+)
+
+// ====================================================================
+// ============================ Data Types ============================
+// ====================================================================
+
+// Error an application error carrying a stable machine code, HTTP status and optional details.
+// Errors are matched by `errors.Is` on code and message, so copies with other details or cause
+// still match their catalog entry.
+type Error struct {
+	Code    string    // Stable machine code. Ex: NOT_FOUND
+	Status  int       // HTTP status code
+	Message string    // Message for clients
+	Details core.Data // Optional details for clients. Ex: invalid fields
+	Err     error     // Optional cause, never sent to clients
+}
+
+// Error implement interface error.
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("%s: %v", e.Message, e.Err)
+	}
+
+	return e.Message
+}
+
+// Unwrap get the cause.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Is match errors having the same code and message.
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+
+	return ok && t.Code == e.Code && t.Message == e.Message
+}
+
+// WithCode get a copy with given code.
+func (e *Error) WithCode(code string) *Error {
+	c := *e
+	c.Code = code
+
+	return &c
+}
+
+// WithDetails get a copy with given details.
+func (e *Error) WithDetails(details core.Data) *Error {
+	c := *e
+	c.Details = details
+
+	return &c
+}
+
+// Wrap get a copy with given cause.
+func (e *Error) Wrap(err error) *Error {
+	c := *e
+	c.Err = err
+
+	return &c
+}
+
+// ====================================================================
+// ============================ Constructors ==========================
+// ====================================================================
+
+// New create an application error.
+func New(status int, code, message string) *Error {
+	return &Error{
+		Code:    code,
+		Status:  status,
+		Message: message,
+	}
+}
+
+// BadRequest create error 400 for malformed requests.
+func BadRequest(message string) *Error {
+	return New(core.StatusBadRequest, constant.ErrCodeBadRequest, message)
+}
+
+// NotFound create error 404 for missing resources.
+func NotFound(message string) *Error {
+	return New(core.StatusNotFound, constant.ErrCodeNotFound, message)
+}
+
+// Validation create error 422 with messages of invalid fields.
+func Validation(message string, fields any) *Error {
+	return New(core.StatusUnprocessableEntity, constant.ErrCodeValidation, message).
+		WithDetails(core.Data{
+			"errors": fields,
+		})
+}
+
+// Conflict create error 409 for resources conflicting with existing ones.
+func Conflict(message string) *Error {
+	return New(core.StatusConflict, constant.ErrCodeConflict, message)
+}
+
+// Unauthorized create error 401 for unauthenticated requests.
+func Unauthorized(message string) *Error {
+	return New(core.StatusUnauthorized, constant.ErrCodeUnauthorized, message)
+}
+
+// Forbidden create error 403 for users without enough privileges.
+func Forbidden(message string) *Error {
+	return New(core.StatusForbidden, constant.ErrCodeForbidden, message)
+}
+
+// RateLimited create error 429 for clients sending too many requests.
+func RateLimited(message string) *Error {
+	return New(core.StatusTooManyRequests, constant.ErrCodeTooManyRequests, message)
+}
+
+// Internal create error 500 wrapping given cause.
+func Internal(err error) *Error {
+	return ErrInternal.Wrap(err)
+}
+
+// From convert any error to an application error. Unknown errors become internal errors.
+func From(err error) *Error {
+	var e *Error
+	if stdErrors.As(err, &e) {
+		return e
+	}
+
+	return Internal(err)
+}
+
+// Is same as standard errors.Is.
+func Is(err, target error) bool {
+	return stdErrors.Is(err, target)
+}
@@ -0,0 +1,85 @@
+package errors
+
+import (
+	"gfly/app/constant"
+	"gfly/app/http/response"
+	"github.com/gflydev/core"
+	"github.com/gflydev/core/log"
+	"github.com/gflydev/core/utils"
+	"strings"
+)
+
+// ====================================================================
+// =========================== Error Handler ==========================
+// ====================================================================
+
+// errorView template of web error page in `resources/views`.
+const errorView = "errors/error"
+
+// Render respond given error. API routes and clients accepting JSON get the error envelope
+// `response.Error`, web routes get an HTML error page. Internal errors are logged with the request ID
+// and their cause is only exposed when APP_DEBUG=true.
+// It returns the rendered *AppError, or the error of writing the response, never nil: a middleware
+// returning it stops the chain before the handler, and handlers return it as is.
+//
+//	user, err := services.GetUser(id)
+//	if err != nil {
+//		return errors.Render(c, err)
+//	}
+func Render(c *core.Ctx, err error) error {
+	e := From(err)
+	requestID := requestIDOf(c)
+
+	details := e.Details
+	if e.Status >= core.StatusInternalServerError {
+		log.Errorf("[%s] %s %s: %v", requestID, c.Method(), c.Path(), err)
+
+		if utils.Getenv("APP_DEBUG", false) && e.Err != nil {
+			details = core.Data{
+				"debug": e.Err.Error(),
+			}
+		}
+	}
+
+	var renderErr error
+	if wantsJSON(c) {
+		renderErr = c.Error(response.Error{
+			Code:      e.Code,
+			Message:   e.Message,
+			Data:      details,
+			RequestID: requestID,
+		}, e.Status)
+	} else {
+		renderErr = c.Status(e.Status).View(errorView, core.Data{
+			"status":     e.Status,
+			"code":       e.Code,
+			"message":    e.Message,
+			"details":    details,
+			"request_id": requestID,
+		})
+	}
+
+	if renderErr != nil {
+		return renderErr
+	}
+
+	return e
+}
+
+// requestIDOf get ID of current request, set by the request ID middleware or given by the client.
+func requestIDOf(c *core.Ctx) string {
+	if requestID, ok := c.GetData(constant.RequestID).(string); ok {
+		return requestID
+	}
+
+	return string(c.Root().Request.Header.Peek(constant.HeaderRequestID))
+}
+
+// wantsJSON check request targets an API route or accepts JSON.
+func wantsJSON(c *core.Ctx) bool {
+	if strings.HasPrefix(c.Path(), "/"+utils.Getenv("API_PREFIX", "api")+"/") {
+		return true
+	}
+
+	return strings.Contains(string(c.Root().Request.Header.Peek("Accept")), "application/json")
+}
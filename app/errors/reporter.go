@@ -0,0 +1,106 @@
+package errors
+
+import (
+	"encoding/json"
+	"github.com/gflydev/core/log"
+	"github.com/gflydev/core/utils"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// ====================================================================
+// ============================ Data Types ============================
+// ====================================================================
+
+// CrashReport a recovered panic of a request.
+type CrashReport struct {
+	Time      time.Time `json:"time"`
+	RequestID string    `json:"request_id"`
+	Method    string    `json:"method"`
+	Path      string    `json:"path"`
+	UserID    int       `json:"user_id,omitempty"`
+	Panic     string    `json:"panic"`
+	Stack     string    `json:"stack"`
+}
+
+// IReporter an interface to forward crash reports to a tracking service.
+type IReporter interface {
+	Report(report CrashReport) error
+}
+
+// ====================================================================
+// ======================== Reporter Registry =========================
+// ====================================================================
+
+var (
+	reporters     []IReporter
+	reportersLock sync.RWMutex
+)
+
+// RegisterReporter add a crash reporter. Register reporters in `main()`.
+//
+//	errors.RegisterReporter(errors.NewFileReporter())
+func RegisterReporter(reporter IReporter) {
+	reportersLock.Lock()
+	defer reportersLock.Unlock()
+
+	reporters = append(reporters, reporter)
+}
+
+// Report forward a crash report to all registered reporters.
+func Report(report CrashReport) {
+	reportersLock.RLock()
+	defer reportersLock.RUnlock()
+
+	for _, reporter := range reporters {
+		if err := reporter.Report(report); err != nil {
+			log.Errorf("[%s] Crash report failed: %v", report.RequestID, err)
+		}
+	}
+}
+
+// ====================================================================
+// ========================== File Reporter ===========================
+// ====================================================================
+
+// FileReporter write crash reports as JSON lines to a local file.
+type FileReporter struct {
+	path string
+	lock sync.Mutex
+}
+
+// NewFileReporter create a file reporter writing to env CRASH_REPORT_FILE (default storage/logs/crash.log).
+func NewFileReporter() *FileReporter {
+	return &FileReporter{
+		path: utils.Getenv("CRASH_REPORT_FILE", "storage/logs/crash.log"),
+	}
+}
+
+// Report implement IReporter.
+func (r *FileReporter) Report(report CrashReport) error {
+	line, err := json.Marshal(report)
+	if err != nil {
+		return err
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if err = os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(r.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	_, err = file.Write(append(line, '\n'))
+
+	return err
+}
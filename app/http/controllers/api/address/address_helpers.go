@@ -0,0 +1,35 @@
+package address
+
+import (
+	"gfly/app/errors"
+	"gfly/app/http/middleware"
+	"gfly/app/services"
+	"github.com/gflydev/core"
+	"strconv"
+)
+
+// authorize allow the owner having permission `own` or other users having permission `other`.
+func authorize(c *core.Ctx, userID int, own, other string) error {
+	currentUser := middleware.CurrentUser(c)
+	if currentUser == nil {
+		return errors.Render(c, errors.ErrUnauthorized)
+	}
+
+	required := other
+	if currentUser.ID == userID {
+		required = own
+	}
+
+	if err := services.Authorize(currentUser.ID, required); err != nil {
+		return errors.Render(c, err)
+	}
+
+	return nil
+}
+
+// pathID get positive ID from given path parameter.
+func pathID(c *core.Ctx, name string) (int, bool) {
+	id, err := strconv.Atoi(c.PathVal(name))
+
+	return id, err == nil && id > 0
+}
@@ -0,0 +1,79 @@
+package address
+
+import (
+	"gfly/app/constant"
+	"gfly/app/domain/models"
+	"gfly/app/dto"
+	"gfly/app/errors"
+	"gfly/app/http/request"
+	"gfly/app/http/transformers"
+	"gfly/app/services"
+	"github.com/gflydev/core"
+)
+
+// ====================================================================
+// ======================== Controller Creation =======================
+// ====================================================================
+
+// NewCreateAddressApi As a constructor to create new API.
+func NewCreateAddressApi() *CreateAddressApi {
+	return &CreateAddressApi{}
+}
+
+// CreateAddressApi API struct.
+type CreateAddressApi struct {
+	core.Api
+}
+
+// ====================================================================
+// ========================= Request Handling =========================
+// ====================================================================
+
+// Validate Verify request data.
+func (h *CreateAddressApi) Validate(c *core.Ctx) error {
+	userID, ok := pathID(c, "id")
+	if !ok {
+		return errors.Render(c, services.ErrUserNotFound)
+	}
+
+	if err := authorize(c, userID, models.PermissionProfileEdit, models.PermissionUsersUpdate); err != nil {
+		return err
+	}
+
+	var createAddress dto.CreateAddress
+	if err := request.Bind(c, &createAddress); err != nil {
+		return request.Reject(c, err)
+	}
+	createAddress.UserID = userID
+
+	c.SetData(constant.Request, createAddress)
+
+	return nil
+}
+
+// Handle Process main logic for API.
+// @Summary Create address
+// @Description Create a new address for a user. The first address of a type becomes default
+// @Tags Addresses
+// @Accept json
+// @Produce json
+// @Param id path int true "User ID"
+// @Param data body dto.CreateAddress true "Address payload"
+// @Success 201 {object} response.Address
+// @Failure 400 {object} response.Error
+// @Failure 401 {object} response.Error
+// @Failure 403 {object} response.Error
+// @Failure 404 {object} response.Error
+// @Failure 422 {object} response.Error
+// @Security ApiKeyAuth
+// @Router /users/{id}/addresses [post]
+func (h *CreateAddressApi) Handle(c *core.Ctx) error {
+	createAddress := c.GetData(constant.Request).(dto.CreateAddress)
+
+	address, err := services.CreateAddress(&createAddress)
+	if err != nil {
+		return errors.Render(c, err)
+	}
+
+	return c.Status(core.StatusCreated).JSON(transformers.ToAddressResponse(*address))
+}
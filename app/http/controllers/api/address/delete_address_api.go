@@ -0,0 +1,64 @@
+package address
+
+import (
+	"gfly/app/domain/models"
+	"gfly/app/errors"
+	"gfly/app/services"
+	"github.com/gflydev/core"
+)
+
+// ====================================================================
+// ======================== Controller Creation =======================
+// ====================================================================
+
+// NewDeleteAddressApi As a constructor to create new API.
+func NewDeleteAddressApi() *DeleteAddressApi {
+	return &DeleteAddressApi{}
+}
+
+// DeleteAddressApi API struct.
+type DeleteAddressApi struct {
+	core.Api
+}
+
+// ====================================================================
+// ========================= Request Handling =========================
+// ====================================================================
+
+// Validate Verify request data.
+func (h *DeleteAddressApi) Validate(c *core.Ctx) error {
+	userID, ok := pathID(c, "id")
+	if !ok {
+		return errors.Render(c, services.ErrUserNotFound)
+	}
+
+	return authorize(c, userID, models.PermissionProfileEdit, models.PermissionUsersUpdate)
+}
+
+// Handle Process main logic for API.
+// @Summary Delete address
+// @Description Delete an address of a user. Another address of the same type becomes default when the deleted one was default
+// @Tags Addresses
+// @Accept json
+// @Produce json
+// @Param id path int true "User ID"
+// @Param address_id path int true "Address ID"
+// @Success 204
+// @Failure 401 {object} response.Error
+// @Failure 403 {object} response.Error
+// @Failure 404 {object} response.Error
+// @Security ApiKeyAuth
+// @Router /users/{id}/addresses/{address_id} [delete]
+func (h *DeleteAddressApi) Handle(c *core.Ctx) error {
+	userID, _ := pathID(c, "id")
+	addressID, ok := pathID(c, "address_id")
+	if !ok {
+		return errors.Render(c, services.ErrAddressNotFound)
+	}
+
+	if err := services.DeleteAddress(userID, addressID); err != nil {
+		return errors.Render(c, err)
+	}
+
+	return c.NoContent()
+}
@@ -0,0 +1,67 @@
+package address
+
+import (
+	"gfly/app/domain/models"
+	"gfly/app/domain/repository"
+	"gfly/app/errors"
+	"gfly/app/http/transformers"
+	"gfly/app/services"
+	"github.com/gflydev/core"
+)
+
+// ====================================================================
+// ======================== Controller Creation =======================
+// ====================================================================
+
+// NewGetAddressApi As a constructor to create new API.
+func NewGetAddressApi() *GetAddressApi {
+	return &GetAddressApi{}
+}
+
+// GetAddressApi API struct.
+type GetAddressApi struct {
+	core.Api
+}
+
+// ====================================================================
+// ========================= Request Handling =========================
+// ====================================================================
+
+// Validate Verify request data.
+func (h *GetAddressApi) Validate(c *core.Ctx) error {
+	userID, ok := pathID(c, "id")
+	if !ok {
+		return errors.Render(c, services.ErrUserNotFound)
+	}
+
+	return authorize(c, userID, models.PermissionProfileView, models.PermissionUsersView)
+}
+
+// Handle Process main logic for API.
+// @Summary Get address
+// @Description Get an address of a user by ID
+// @Tags Addresses
+// @Accept json
+// @Produce json
+// @Param id path int true "User ID"
+// @Param address_id path int true "Address ID"
+// @Success 200 {object} response.Address
+// @Failure 401 {object} response.Error
+// @Failure 403 {object} response.Error
+// @Failure 404 {object} response.Error
+// @Security ApiKeyAuth
+// @Router /users/{id}/addresses/{address_id} [get]
+func (h *GetAddressApi) Handle(c *core.Ctx) error {
+	userID, _ := pathID(c, "id")
+	addressID, ok := pathID(c, "address_id")
+	if !ok {
+		return errors.Render(c, services.ErrAddressNotFound)
+	}
+
+	address := repository.Pool.GetAddressByID(userID, addressID)
+	if address == nil {
+		return errors.Render(c, services.ErrAddressNotFound)
+	}
+
+	return c.JSON(transformers.ToAddressResponse(*address))
+}
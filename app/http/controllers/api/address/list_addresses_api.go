@@ -0,0 +1,61 @@
+package address
+
+import (
+	"gfly/app/domain/models"
+	"gfly/app/domain/repository"
+	"gfly/app/errors"
+	"gfly/app/http/transformers"
+	"gfly/app/services"
+	"github.com/gflydev/core"
+)
+
+// ====================================================================
+// ======================== Controller Creation =======================
+// ====================================================================
+
+// NewListAddressesApi As a constructor to create new API.
+func NewListAddressesApi() *ListAddressesApi {
+	return &ListAddressesApi{}
+}
+
+// ListAddressesApi API struct.
+type ListAddressesApi struct {
+	core.Api
+}
+
+// ====================================================================
+// ========================= Request Handling =========================
+// ====================================================================
+
+// Validate Verify request data.
+func (h *ListAddressesApi) Validate(c *core.Ctx) error {
+	userID, ok := pathID(c, "id")
+	if !ok {
+		return errors.Render(c, services.ErrUserNotFound)
+	}
+
+	return authorize(c, userID, models.PermissionProfileView, models.PermissionUsersView)
+}
+
+// Handle Process main logic for API.
+// @Summary List addresses
+// @Description Get all addresses of a user
+// @Tags Addresses
+// @Accept json
+// @Produce json
+// @Param id path int true "User ID"
+// @Success 200 {object} response.ListAddress
+// @Failure 401 {object} response.Error
+// @Failure 403 {object} response.Error
+// @Failure 404 {object} response.Error
+// @Security ApiKeyAuth
+// @Router /users/{id}/addresses [get]
+func (h *ListAddressesApi) Handle(c *core.Ctx) error {
+	userID, _ := pathID(c, "id")
+
+	if repository.Pool.GetUserByID(userID) == nil {
+		return errors.Render(c, services.ErrUserNotFound)
+	}
+
+	return c.JSON(transformers.ToListAddressResponse(repository.Pool.GetAddressesByUserID(userID)))
+}
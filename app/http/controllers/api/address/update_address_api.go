@@ -0,0 +1,86 @@
+package address
+
+import (
+	"gfly/app/constant"
+	"gfly/app/domain/models"
+	"gfly/app/dto"
+	"gfly/app/errors"
+	"gfly/app/http/request"
+	"gfly/app/http/transformers"
+	"gfly/app/services"
+	"github.com/gflydev/core"
+)
+
+// ====================================================================
+// ======================== Controller Creation =======================
+// ====================================================================
+
+// NewUpdateAddressApi As a constructor to create new API.
+func NewUpdateAddressApi() *UpdateAddressApi {
+	return &UpdateAddressApi{}
+}
+
+// UpdateAddressApi API struct.
+type UpdateAddressApi struct {
+	core.Api
+}
+
+// ====================================================================
+// ========================= Request Handling =========================
+// ====================================================================
+
+// Validate Verify request data.
+func (h *UpdateAddressApi) Validate(c *core.Ctx) error {
+	userID, ok := pathID(c, "id")
+	if !ok {
+		return errors.Render(c, services.ErrUserNotFound)
+	}
+
+	if err := authorize(c, userID, models.PermissionProfileEdit, models.PermissionUsersUpdate); err != nil {
+		return err
+	}
+
+	addressID, ok := pathID(c, "address_id")
+	if !ok {
+		return errors.Render(c, services.ErrAddressNotFound)
+	}
+
+	var updateAddress dto.UpdateAddress
+	if err := request.Bind(c, &updateAddress); err != nil {
+		return request.Reject(c, err)
+	}
+	updateAddress.ID = addressID
+	updateAddress.UserID = userID
+
+	c.SetData(constant.Request, updateAddress)
+
+	return nil
+}
+
+// Handle Process main logic for API.
+// @Summary Update address
+// @Description Update an address of a user. Setting `is_default` moves the default flag from other addresses of the same type
+// @Tags Addresses
+// @Accept json
+// @Produce json
+// @Param id path int true "User ID"
+// @Param address_id path int true "Address ID"
+// @Param data body dto.UpdateAddress true "Address payload"
+// @Success 200 {object} response.Address
+// @Failure 400 {object} response.Error
+// @Failure 401 {object} response.Error
+// @Failure 403 {object} response.Error
+// @Failure 404 {object} response.Error
+// @Failure 422 {object} response.Error
+// @Security ApiKeyAuth
+// @Router /users/{id}/addresses/{address_id} [put]
+func (h *UpdateAddressApi) Handle(c *core.Ctx) error {
+	updateAddress := c.GetData(constant.Request).(dto.UpdateAddress)
+
+	address, err := services.UpdateAddress(&updateAddress)
+	if err != nil {
+		return errors.Render(c, err)
+	}
+
+	return c.JSON(transformers.ToAddressResponse(*address))
+}
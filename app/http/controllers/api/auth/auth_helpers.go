@@ -0,0 +1,15 @@
+package auth
+
+import (
+	"gfly/app/http/response"
+	"gfly/app/services"
+)
+
+// tokenResponse convert issued tokens to response.
+func tokenResponse(tokens *services.Tokens) response.Token {
+	return response.Token{
+		Access:    tokens.Access,
+		Refresh:   tokens.Refresh,
+		ExpiresAt: tokens.ExpiresAt.Unix(),
+	}
+}
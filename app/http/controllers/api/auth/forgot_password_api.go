@@ -0,0 +1,67 @@
+package auth
+
+import (
+	"gfly/app/constant"
+	"gfly/app/dto"
+	"gfly/app/errors"
+	"gfly/app/http/middleware"
+	"gfly/app/http/request"
+	"gfly/app/http/response"
+	"gfly/app/services"
+	"github.com/gflydev/core"
+)
+
+// ====================================================================
+// ======================== Controller Creation =======================
+// ====================================================================
+
+// NewForgotPasswordApi As a constructor to create new API.
+func NewForgotPasswordApi() *ForgotPasswordApi {
+	return &ForgotPasswordApi{}
+}
+
+// ForgotPasswordApi API struct.
+type ForgotPasswordApi struct {
+	core.Api
+}
+
+// ====================================================================
+// ========================= Request Handling =========================
+// ====================================================================
+
+// Validate Verify request data.
+func (h *ForgotPasswordApi) Validate(c *core.Ctx) error {
+	var forgotPassword dto.ForgotPassword
+	if err := request.Bind(c, &forgotPassword); err != nil {
+		return request.Reject(c, err)
+	}
+	forgotPassword.RequestID = middleware.CurrentRequestID(c)
+
+	c.SetData(constant.Request, forgotPassword)
+
+	return nil
+}
+
+// Handle Process main logic for API.
+// @Summary Forgot password
+// @Description Email a single-use password reset link. The response is the same whether the email exists or not.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param data body dto.ForgotPassword true "Forgot password payload"
+// @Success 202 {object} response.Message
+// @Failure 400 {object} response.Error
+// @Failure 422 {object} response.Error
+// @Failure 429 {object} response.Error
+// @Router /auth/forgot-password [post]
+func (h *ForgotPasswordApi) Handle(c *core.Ctx) error {
+	forgotPassword := c.GetData(constant.Request).(dto.ForgotPassword)
+
+	if err := services.ForgotPassword(middleware.RequestContext(c), &forgotPassword); err != nil {
+		return errors.Render(c, err)
+	}
+
+	return c.Status(core.StatusAccepted).JSON(response.Message{
+		Message: "If the email exists, a reset link has been sent",
+	})
+}
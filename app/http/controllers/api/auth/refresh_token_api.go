@@ -0,0 +1,64 @@
+package auth
+
+import (
+	"gfly/app/constant"
+	"gfly/app/dto"
+	"gfly/app/errors"
+	"gfly/app/http/request"
+	"gfly/app/services"
+	"github.com/gflydev/core"
+)
+
+// ====================================================================
+// ======================== Controller Creation =======================
+// ====================================================================
+
+// NewRefreshTokenApi As a constructor to create new API.
+func NewRefreshTokenApi() *RefreshTokenApi {
+	return &RefreshTokenApi{}
+}
+
+// RefreshTokenApi API struct.
+type RefreshTokenApi struct {
+	core.Api
+}
+
+// ====================================================================
+// ========================= Request Handling =========================
+// ====================================================================
+
+// Validate Verify request data.
+func (h *RefreshTokenApi) Validate(c *core.Ctx) error {
+	var refreshToken dto.RefreshToken
+	if err := request.Bind(c, &refreshToken); err != nil {
+		return request.Reject(c, err)
+	}
+
+	c.SetData(constant.Request, refreshToken)
+
+	return nil
+}
+
+// Handle Process main logic for API.
+// @Summary Refresh token
+// @Description Exchange a refresh token for a new access & refresh token pair. The given refresh token can't be used again.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param data body dto.RefreshToken true "Refresh token payload"
+// @Success 200 {object} response.Token
+// @Failure 400 {object} response.Error
+// @Failure 422 {object} response.Error
+// @Failure 401 {object} response.Error
+// @Failure 403 {object} response.Error
+// @Router /auth/refresh [post]
+func (h *RefreshTokenApi) Handle(c *core.Ctx) error {
+	refreshToken := c.GetData(constant.Request).(dto.RefreshToken)
+
+	tokens, err := services.RefreshToken(refreshToken.Token)
+	if err != nil {
+		return errors.Render(c, err)
+	}
+
+	return c.JSON(tokenResponse(tokens))
+}
@@ -0,0 +1,67 @@
+package auth
+
+import (
+	"gfly/app/constant"
+	"gfly/app/dto"
+	"gfly/app/errors"
+	"gfly/app/http/middleware"
+	"gfly/app/http/request"
+	"gfly/app/http/response"
+	"gfly/app/services"
+	"github.com/gflydev/core"
+)
+
+// ====================================================================
+// ======================== Controller Creation =======================
+// ====================================================================
+
+// NewResendVerificationApi As a constructor to create new API.
+func NewResendVerificationApi() *ResendVerificationApi {
+	return &ResendVerificationApi{}
+}
+
+// ResendVerificationApi API struct.
+type ResendVerificationApi struct {
+	core.Api
+}
+
+// ====================================================================
+// ========================= Request Handling =========================
+// ====================================================================
+
+// Validate Verify request data.
+func (h *ResendVerificationApi) Validate(c *core.Ctx) error {
+	var resend dto.ResendVerification
+	if err := request.Bind(c, &resend); err != nil {
+		return request.Reject(c, err)
+	}
+	resend.RequestID = middleware.CurrentRequestID(c)
+
+	c.SetData(constant.Request, resend)
+
+	return nil
+}
+
+// Handle Process main logic for API.
+// @Summary Resend verification email
+// @Description Email a new verification link. The response is the same whether the email exists or not.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param data body dto.ResendVerification true "Resend verification payload"
+// @Success 202 {object} response.Message
+// @Failure 400 {object} response.Error
+// @Failure 422 {object} response.Error
+// @Failure 429 {object} response.Error
+// @Router /auth/verify-email/resend [post]
+func (h *ResendVerificationApi) Handle(c *core.Ctx) error {
+	resend := c.GetData(constant.Request).(dto.ResendVerification)
+
+	if err := services.ResendVerificationEmail(middleware.RequestContext(c), &resend); err != nil {
+		return errors.Render(c, err)
+	}
+
+	return c.Status(core.StatusAccepted).JSON(response.Message{
+		Message: "If the email needs verification, a new link has been sent",
+	})
+}
@@ -0,0 +1,65 @@
+package auth
+
+import (
+	"gfly/app/constant"
+	"gfly/app/dto"
+	"gfly/app/errors"
+	"gfly/app/http/request"
+	"gfly/app/http/response"
+	"gfly/app/services"
+	"github.com/gflydev/core"
+)
+
+// ====================================================================
+// ======================== Controller Creation =======================
+// ====================================================================
+
+// NewResetPasswordApi As a constructor to create new API.
+func NewResetPasswordApi() *ResetPasswordApi {
+	return &ResetPasswordApi{}
+}
+
+// ResetPasswordApi API struct.
+type ResetPasswordApi struct {
+	core.Api
+}
+
+// ====================================================================
+// ========================= Request Handling =========================
+// ====================================================================
+
+// Validate Verify request data.
+func (h *ResetPasswordApi) Validate(c *core.Ctx) error {
+	var resetPassword dto.ResetPassword
+	if err := request.Bind(c, &resetPassword); err != nil {
+		return request.Reject(c, err)
+	}
+
+	c.SetData(constant.Request, resetPassword)
+
+	return nil
+}
+
+// Handle Process main logic for API.
+// @Summary Reset password
+// @Description Set a new password by a reset token from the emailed link. The token can't be used again.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param data body dto.ResetPassword true "Reset password payload"
+// @Success 200 {object} response.Message
+// @Failure 400 {object} response.Error
+// @Failure 422 {object} response.Error
+// @Failure 429 {object} response.Error
+// @Router /auth/reset-password [post]
+func (h *ResetPasswordApi) Handle(c *core.Ctx) error {
+	resetPassword := c.GetData(constant.Request).(dto.ResetPassword)
+
+	if err := services.ResetPassword(&resetPassword); err != nil {
+		return errors.Render(c, err)
+	}
+
+	return c.JSON(response.Message{
+		Message: "Password has been reset",
+	})
+}
@@ -0,0 +1,65 @@
+package auth
+
+import (
+	"gfly/app/constant"
+	"gfly/app/dto"
+	"gfly/app/errors"
+	"gfly/app/http/request"
+	"gfly/app/services"
+	"github.com/gflydev/core"
+)
+
+// ====================================================================
+// ======================== Controller Creation =======================
+// ====================================================================
+
+// NewSignInApi As a constructor to create new API.
+func NewSignInApi() *SignInApi {
+	return &SignInApi{}
+}
+
+// SignInApi API struct.
+type SignInApi struct {
+	core.Api
+}
+
+// ====================================================================
+// ========================= Request Handling =========================
+// ====================================================================
+
+// Validate Verify request data.
+func (h *SignInApi) Validate(c *core.Ctx) error {
+	var signIn dto.SignIn
+	if err := request.Bind(c, &signIn); err != nil {
+		return request.Reject(c, err)
+	}
+
+	c.SetData(constant.Request, signIn)
+
+	return nil
+}
+
+// Handle Process main logic for API.
+// @Summary Sign in
+// @Description Authenticate user by email & password and issue access & refresh tokens
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param data body dto.SignIn true "Sign in payload"
+// @Success 200 {object} response.Token
+// @Failure 400 {object} response.Error
+// @Failure 422 {object} response.Error
+// @Failure 401 {object} response.Error
+// @Failure 403 {object} response.Error
+// @Failure 429 {object} response.Error
+// @Router /auth/signin [post]
+func (h *SignInApi) Handle(c *core.Ctx) error {
+	signIn := c.GetData(constant.Request).(dto.SignIn)
+
+	tokens, err := services.SignIn(&signIn)
+	if err != nil {
+		return errors.Render(c, err)
+	}
+
+	return c.JSON(tokenResponse(tokens))
+}
@@ -0,0 +1,60 @@
+package auth
+
+import (
+	"gfly/app/constant"
+	"gfly/app/dto"
+	"gfly/app/errors"
+	"gfly/app/http/request"
+	"gfly/app/services"
+	"github.com/gflydev/core"
+)
+
+// ====================================================================
+// ======================== Controller Creation =======================
+// ====================================================================
+
+// NewSignOutApi As a constructor to create new API.
+func NewSignOutApi() *SignOutApi {
+	return &SignOutApi{}
+}
+
+// SignOutApi API struct.
+type SignOutApi struct {
+	core.Api
+}
+
+// ====================================================================
+// ========================= Request Handling =========================
+// ====================================================================
+
+// Validate Verify request data.
+func (h *SignOutApi) Validate(c *core.Ctx) error {
+	// Refresh token is optional
+	var refreshToken dto.RefreshToken
+	_ = request.Decode(c, &refreshToken)
+
+	c.SetData(constant.Request, refreshToken)
+
+	return nil
+}
+
+// Handle Process main logic for API.
+// @Summary Sign out
+// @Description Revoke current access token and given refresh token
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param data body dto.RefreshToken false "Refresh token payload"
+// @Success 204
+// @Failure 401 {object} response.Error
+// @Security ApiKeyAuth
+// @Router /auth/signout [post]
+func (h *SignOutApi) Handle(c *core.Ctx) error {
+	refreshToken := c.GetData(constant.Request).(dto.RefreshToken)
+
+	if err := services.SignOut(services.ExtractToken(c), refreshToken.Token); err != nil {
+		return errors.Render(c, err)
+	}
+
+	return c.NoContent()
+}
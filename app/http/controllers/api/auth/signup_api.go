@@ -0,0 +1,67 @@
+package auth
+
+import (
+	"gfly/app/constant"
+	"gfly/app/dto"
+	"gfly/app/errors"
+	"gfly/app/http/middleware"
+	"gfly/app/http/request"
+	"gfly/app/http/transformers"
+	"gfly/app/services"
+	"github.com/gflydev/core"
+)
+
+// ====================================================================
+// ======================== Controller Creation =======================
+// ====================================================================
+
+// NewSignUpApi As a constructor to create new API.
+func NewSignUpApi() *SignUpApi {
+	return &SignUpApi{}
+}
+
+// SignUpApi API struct.
+type SignUpApi struct {
+	core.Api
+}
+
+// ====================================================================
+// ========================= Request Handling =========================
+// ====================================================================
+
+// Validate Verify request data.
+func (h *SignUpApi) Validate(c *core.Ctx) error {
+	var signUp dto.SignUp
+	if err := request.Bind(c, &signUp); err != nil {
+		return request.Reject(c, err)
+	}
+	signUp.RequestID = middleware.CurrentRequestID(c)
+
+	c.SetData(constant.Request, signUp)
+
+	return nil
+}
+
+// Handle Process main logic for API.
+// @Summary Sign up
+// @Description Register a new user in `pending` status. A verification email is sent to activate the account.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param data body dto.SignUp true "Sign up payload"
+// @Success 201 {object} response.User
+// @Failure 400 {object} response.Error
+// @Failure 422 {object} response.Error
+// @Failure 409 {object} response.Error
+// @Failure 429 {object} response.Error
+// @Router /auth/signup [post]
+func (h *SignUpApi) Handle(c *core.Ctx) error {
+	signUp := c.GetData(constant.Request).(dto.SignUp)
+
+	user, err := services.SignUp(middleware.RequestContext(c), &signUp)
+	if err != nil {
+		return errors.Render(c, err)
+	}
+
+	return c.Status(core.StatusCreated).JSON(transformers.ToUserResponse(*user))
+}
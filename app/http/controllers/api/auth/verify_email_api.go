@@ -0,0 +1,67 @@
+package auth
+
+import (
+	"gfly/app/constant"
+	"gfly/app/dto"
+	"gfly/app/errors"
+	"gfly/app/http/request"
+	"gfly/app/http/response"
+	"gfly/app/services"
+	"github.com/gflydev/core"
+)
+
+// ====================================================================
+// ======================== Controller Creation =======================
+// ====================================================================
+
+// NewVerifyEmailApi As a constructor to create new API.
+func NewVerifyEmailApi() *VerifyEmailApi {
+	return &VerifyEmailApi{}
+}
+
+// VerifyEmailApi API struct.
+type VerifyEmailApi struct {
+	core.Api
+}
+
+// ====================================================================
+// ========================= Request Handling =========================
+// ====================================================================
+
+// Validate Verify request data.
+func (h *VerifyEmailApi) Validate(c *core.Ctx) error {
+	var verifyEmail dto.VerifyEmail
+	if err := request.Bind(c, &verifyEmail); err != nil {
+		// Any malformed link is reported as an invalid verification
+		return errors.Render(c, services.ErrInvalidVerification)
+	}
+
+	c.SetData(constant.Request, verifyEmail)
+
+	return nil
+}
+
+// Handle Process main logic for API.
+// @Summary Verify email
+// @Description Verify email by the signed link sent to user. Pending user becomes active.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param id query int true "User ID"
+// @Param hash query string true "Email hash"
+// @Param expires query int true "Expiration timestamp"
+// @Param signature query string true "Link signature"
+// @Success 200 {object} response.Message
+// @Failure 400 {object} response.Error
+// @Router /auth/verify-email [get]
+func (h *VerifyEmailApi) Handle(c *core.Ctx) error {
+	verifyEmail := c.GetData(constant.Request).(dto.VerifyEmail)
+
+	if _, err := services.VerifyEmail(&verifyEmail); err != nil {
+		return errors.Render(c, err)
+	}
+
+	return c.JSON(response.Message{
+		Message: "Email has been verified",
+	})
+}
@@ -0,0 +1,98 @@
+package api
+
+import (
+	"gfly/app/http/response"
+	"gfly/app/services"
+	"github.com/gflydev/core"
+	"github.com/gflydev/core/utils"
+)
+
+// ====================================================================
+// ======================== Controller Creation =======================
+// ====================================================================
+
+// NewHealthApi As a constructor to create new liveness API.
+func NewHealthApi() *HealthApi {
+	return &HealthApi{}
+}
+
+// HealthApi API struct.
+type HealthApi struct {
+	core.Api
+}
+
+// NewReadyApi As a constructor to create new readiness API.
+func NewReadyApi() *ReadyApi {
+	return &ReadyApi{}
+}
+
+// ReadyApi API struct.
+type ReadyApi struct {
+	core.Api
+}
+
+// ====================================================================
+// ========================= Request Handling =========================
+// ====================================================================
+
+// Handle Process main logic for API.
+// @Summary Liveness probe
+// @Description Check the process is alive. It does not touch any dependency.
+// @Tags Misc
+// @Produce json
+// @Success 200 {object} response.Health
+// @Router /healthz [get]
+func (h *HealthApi) Handle(c *core.Ctx) error {
+	return c.JSON(response.Health{
+		Status: services.HealthUp,
+	})
+}
+
+// Handle Process main logic for API.
+// @Summary Readiness probe
+// @Description Check database, Redis, session store, storage and SMTP with per-check latency.
+// @Description Fail when a critical dependency is down, SMTP is only reported. Results are cached for
+// @Description HEALTH_CHECK_CACHE milliseconds. Fail while the application is shutting down so load balancers drain traffic.
+// @Tags Misc
+// @Produce json
+// @Success 200 {object} response.Health
+// @Failure 503 {object} response.Health
+// @Router /readyz [get]
+func (h *ReadyApi) Handle(c *core.Ctx) error {
+	results, ready := services.CheckReadiness()
+
+	debug := utils.Getenv("APP_DEBUG", false)
+	checks := make([]response.HealthCheck, 0, len(results))
+	for _, result := range results {
+		check := response.HealthCheck{
+			Name:      result.Name,
+			Status:    result.Status,
+			Critical:  result.Critical,
+			LatencyMs: float64(result.Latency.Microseconds()) / 1000,
+		}
+		if result.Err != nil {
+			// Do not leak hosts and credentials of dependencies
+			check.Error = "unavailable"
+			if debug {
+				check.Error = result.Err.Error()
+			}
+		}
+
+		checks = append(checks, check)
+	}
+
+	obj := response.Health{
+		Status: services.HealthUp,
+		Checks: checks,
+	}
+	if !ready {
+		obj.Status = services.HealthDown
+		if services.IsShuttingDown() {
+			obj.Status = services.HealthShuttingDown
+		}
+
+		return c.Status(core.StatusServiceUnavailable).JSON(obj)
+	}
+
+	return c.JSON(obj)
+}
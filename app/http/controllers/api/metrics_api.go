@@ -0,0 +1,39 @@
+package api
+
+import (
+	"gfly/app/metrics"
+	"github.com/gflydev/core"
+)
+
+// ====================================================================
+// ======================== Controller Creation =======================
+// ====================================================================
+
+// NewMetricsApi As a constructor to create new metrics API.
+func NewMetricsApi() *MetricsApi {
+	return &MetricsApi{}
+}
+
+// MetricsApi API struct.
+type MetricsApi struct {
+	core.Api
+}
+
+// ====================================================================
+// ========================= Request Handling =========================
+// ====================================================================
+
+// Handle Process main logic for API.
+// @Summary Prometheus metrics
+// @Description Get HTTP, database pool, cache, queue and scheduler metrics in Prometheus text format.
+// @Description Require header `Authorization: Bearer <METRICS_TOKEN>` when the token is set.
+// @Tags Misc
+// @Produce plain
+// @Success 200 {string} string
+// @Failure 401 {string} string
+// @Router /metrics [get]
+func (h *MetricsApi) Handle(c *core.Ctx) error {
+	metrics.Handle(c.Root())
+
+	return nil
+}
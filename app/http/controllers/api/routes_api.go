@@ -0,0 +1,38 @@
+package api
+
+import (
+	"gfly/app/http/response"
+	"github.com/gflydev/core"
+)
+
+// ====================================================================
+// ======================== Controller Creation =======================
+// ====================================================================
+
+// NewRoutesApi As a constructor to create new routes API. Routes are given by finder.
+func NewRoutesApi(finder func(method, prefix string) []response.Route) *RoutesApi {
+	return &RoutesApi{finder: finder}
+}
+
+// RoutesApi API struct.
+type RoutesApi struct {
+	core.Api
+	finder func(method, prefix string) []response.Route
+}
+
+// ====================================================================
+// ========================= Request Handling =========================
+// ====================================================================
+
+// Handle Process main logic for API.
+// @Summary List routes
+// @Description Get mounted routes with full path, handler type and middlewares. Only available when APP_DEBUG=true.
+// @Tags Misc
+// @Produce json
+// @Param method query string false "HTTP method"
+// @Param prefix query string false "Path prefix"
+// @Success 200 {array} response.Route
+// @Router /debug/routes [get]
+func (h *RoutesApi) Handle(c *core.Ctx) error {
+	return c.JSON(h.finder(c.QueryStr("method"), c.QueryStr("prefix")))
+}
@@ -0,0 +1,70 @@
+package user
+
+import (
+	"gfly/app/constant"
+	"gfly/app/dto"
+	"gfly/app/errors"
+	"gfly/app/http/middleware"
+	"gfly/app/http/request"
+	"gfly/app/http/transformers"
+	"gfly/app/services"
+	"github.com/gflydev/core"
+)
+
+// ====================================================================
+// ======================== Controller Creation =======================
+// ====================================================================
+
+// NewCreateUserApi As a constructor to create new API.
+func NewCreateUserApi() *CreateUserApi {
+	return &CreateUserApi{}
+}
+
+// CreateUserApi API struct.
+type CreateUserApi struct {
+	core.Api
+}
+
+// ====================================================================
+// ========================= Request Handling =========================
+// ====================================================================
+
+// Validate Verify request data.
+func (h *CreateUserApi) Validate(c *core.Ctx) error {
+	var createUser dto.CreateUser
+	if err := request.Bind(c, &createUser); err != nil {
+		return request.Reject(c, err)
+	}
+	createUser.RequestID = middleware.CurrentRequestID(c)
+	createUser.ActorID = middleware.CurrentUser(c).ID
+
+	c.SetData(constant.Request, createUser)
+
+	return nil
+}
+
+// Handle Process main logic for API.
+// @Summary Create user
+// @Description Create a new user with roles. Giving roles needs permission `roles.assign`, a blocked status needs `users.block`
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Param data body dto.CreateUser true "User payload"
+// @Success 201 {object} response.User
+// @Failure 400 {object} response.Error
+// @Failure 422 {object} response.Error
+// @Failure 401 {object} response.Error
+// @Failure 403 {object} response.Error
+// @Failure 409 {object} response.Error
+// @Security ApiKeyAuth
+// @Router /users [post]
+func (h *CreateUserApi) Handle(c *core.Ctx) error {
+	createUser := c.GetData(constant.Request).(dto.CreateUser)
+
+	user, err := services.CreateUser(middleware.RequestContext(c), &createUser)
+	if err != nil {
+		return errors.Render(c, err)
+	}
+
+	return c.Status(core.StatusCreated).JSON(transformers.ToUserResponse(*user))
+}
@@ -0,0 +1,57 @@
+package user
+
+import (
+	"gfly/app/errors"
+	"gfly/app/http/middleware"
+	"gfly/app/services"
+	"github.com/gflydev/core"
+)
+
+// ====================================================================
+// ======================== Controller Creation =======================
+// ====================================================================
+
+// NewDeleteUserApi As a constructor to create new API.
+func NewDeleteUserApi() *DeleteUserApi {
+	return &DeleteUserApi{}
+}
+
+// DeleteUserApi API struct.
+type DeleteUserApi struct {
+	core.Api
+}
+
+// ====================================================================
+// ========================= Request Handling =========================
+// ====================================================================
+
+// Handle Process main logic for API.
+// @Summary Delete user
+// @Description Soft delete a user by ID. Deleted user can be restored later
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Param id path int true "User ID"
+// @Success 204
+// @Failure 400 {object} response.Error
+// @Failure 401 {object} response.Error
+// @Failure 403 {object} response.Error
+// @Failure 404 {object} response.Error
+// @Security ApiKeyAuth
+// @Router /users/{id} [delete]
+func (h *DeleteUserApi) Handle(c *core.Ctx) error {
+	userID, ok := pathID(c)
+	if !ok {
+		return errors.Render(c, services.ErrUserNotFound)
+	}
+
+	if currentUser := middleware.CurrentUser(c); currentUser != nil && currentUser.ID == userID {
+		return errors.Render(c, errors.BadRequest("Can not delete yourself"))
+	}
+
+	if err := services.DeleteUser(userID); err != nil {
+		return errors.Render(c, err)
+	}
+
+	return c.NoContent()
+}
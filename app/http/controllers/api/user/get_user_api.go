@@ -0,0 +1,54 @@
+package user
+
+import (
+	"gfly/app/domain/repository"
+	"gfly/app/errors"
+	"gfly/app/http/transformers"
+	"gfly/app/services"
+	"github.com/gflydev/core"
+)
+
+// ====================================================================
+// ======================== Controller Creation =======================
+// ====================================================================
+
+// NewGetUserApi As a constructor to create new API.
+func NewGetUserApi() *GetUserApi {
+	return &GetUserApi{}
+}
+
+// GetUserApi API struct.
+type GetUserApi struct {
+	core.Api
+}
+
+// ====================================================================
+// ========================= Request Handling =========================
+// ====================================================================
+
+// Handle Process main logic for API.
+// @Summary Get user
+// @Description Get user detail by ID
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Param id path int true "User ID"
+// @Success 200 {object} response.User
+// @Failure 401 {object} response.Error
+// @Failure 403 {object} response.Error
+// @Failure 404 {object} response.Error
+// @Security ApiKeyAuth
+// @Router /users/{id} [get]
+func (h *GetUserApi) Handle(c *core.Ctx) error {
+	userID, ok := pathID(c)
+	if !ok {
+		return errors.Render(c, services.ErrUserNotFound)
+	}
+
+	user := repository.Pool.GetUserByID(userID)
+	if user == nil {
+		return errors.Render(c, services.ErrUserNotFound)
+	}
+
+	return c.JSON(transformers.ToUserResponse(*user))
+}
@@ -0,0 +1,90 @@
+package user
+
+import (
+	"gfly/app/constant"
+	"gfly/app/domain/repository"
+	"gfly/app/dto"
+	"gfly/app/http/request"
+	"gfly/app/http/transformers"
+	"github.com/gflydev/core"
+)
+
+// ====================================================================
+// ======================== Controller Creation =======================
+// ====================================================================
+
+// NewListUsersApi As a constructor to create new API.
+func NewListUsersApi() *ListUsersApi {
+	return &ListUsersApi{}
+}
+
+// ListUsersApi API struct.
+type ListUsersApi struct {
+	core.Api
+}
+
+// Pagination limits
+const (
+	defaultPerPage = 20
+	maxPerPage     = 100
+)
+
+// ====================================================================
+// ========================= Request Handling =========================
+// ====================================================================
+
+// Validate Verify request data.
+func (h *ListUsersApi) Validate(c *core.Ctx) error {
+	var filter dto.UserFilter
+	if err := request.Bind(c, &filter); err != nil {
+		return request.Reject(c, err)
+	}
+
+	if filter.Page == 0 {
+		filter.Page = 1
+	}
+	if filter.PerPage == 0 {
+		filter.PerPage = defaultPerPage
+	}
+	filter.PerPage = min(filter.PerPage, maxPerPage)
+
+	c.SetData(constant.Request, filter)
+
+	return nil
+}
+
+// Handle Process main logic for API.
+// @Summary List users
+// @Description Get a paginated list of users with filtering, searching and sorting
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Param keyword query string false "Search on email or fullname"
+// @Param status query string false "Filter by status" Enums(active, pending, blocked)
+// @Param role query string false "Filter by role slug"
+// @Param sort query string false "Sort field" Enums(created_at, last_access_at)
+// @Param order query string false "Sort order" Enums(asc, desc)
+// @Param trashed query string false "Include deleted users" Enums(with, only)
+// @Param page query int false "Page number"
+// @Param per_page query int false "Items per page"
+// @Success 200 {object} response.ListUser
+// @Failure 400 {object} response.Error
+// @Failure 422 {object} response.Error
+// @Failure 401 {object} response.Error
+// @Failure 403 {object} response.Error
+// @Security ApiKeyAuth
+// @Router /users [get]
+func (h *ListUsersApi) Handle(c *core.Ctx) error {
+	filter := c.GetData(constant.Request).(dto.UserFilter)
+
+	users, total := repository.Pool.FindUsers(filter)
+
+	// Load roles of the whole page at once
+	userIDs := make([]int, 0, len(users))
+	for _, user := range users {
+		userIDs = append(userIDs, user.ID)
+	}
+	roles := repository.Pool.GetRolesByUserIDs(userIDs)
+
+	return c.JSON(transformers.ToListUserResponse(users, roles, filter.Page, filter.PerPage, total))
+}
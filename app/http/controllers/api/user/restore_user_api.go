@@ -0,0 +1,53 @@
+package user
+
+import (
+	"gfly/app/errors"
+	"gfly/app/http/transformers"
+	"gfly/app/services"
+	"github.com/gflydev/core"
+)
+
+// ====================================================================
+// ======================== Controller Creation =======================
+// ====================================================================
+
+// NewRestoreUserApi As a constructor to create new API.
+func NewRestoreUserApi() *RestoreUserApi {
+	return &RestoreUserApi{}
+}
+
+// RestoreUserApi API struct.
+type RestoreUserApi struct {
+	core.Api
+}
+
+// ====================================================================
+// ========================= Request Handling =========================
+// ====================================================================
+
+// Handle Process main logic for API.
+// @Summary Restore user
+// @Description Restore a soft deleted user by ID
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Param id path int true "User ID"
+// @Success 200 {object} response.User
+// @Failure 401 {object} response.Error
+// @Failure 403 {object} response.Error
+// @Failure 404 {object} response.Error
+// @Security ApiKeyAuth
+// @Router /users/{id}/restore [post]
+func (h *RestoreUserApi) Handle(c *core.Ctx) error {
+	userID, ok := pathID(c)
+	if !ok {
+		return errors.Render(c, services.ErrUserNotFound)
+	}
+
+	user, err := services.RestoreUser(userID)
+	if err != nil {
+		return errors.Render(c, err)
+	}
+
+	return c.JSON(transformers.ToUserResponse(*user))
+}
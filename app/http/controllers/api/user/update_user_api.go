@@ -0,0 +1,76 @@
+package user
+
+import (
+	"gfly/app/constant"
+	"gfly/app/dto"
+	"gfly/app/errors"
+	"gfly/app/http/middleware"
+	"gfly/app/http/request"
+	"gfly/app/http/transformers"
+	"gfly/app/services"
+	"github.com/gflydev/core"
+)
+
+// ====================================================================
+// ======================== Controller Creation =======================
+// ====================================================================
+
+// NewUpdateUserApi As a constructor to create new API.
+func NewUpdateUserApi() *UpdateUserApi {
+	return &UpdateUserApi{}
+}
+
+// UpdateUserApi API struct.
+type UpdateUserApi struct {
+	core.Api
+}
+
+// ====================================================================
+// ========================= Request Handling =========================
+// ====================================================================
+
+// Validate Verify request data.
+func (h *UpdateUserApi) Validate(c *core.Ctx) error {
+	userID, ok := pathID(c)
+	if !ok {
+		return errors.Render(c, services.ErrUserNotFound)
+	}
+
+	var updateUser dto.UpdateUser
+	if err := request.Bind(c, &updateUser); err != nil {
+		return request.Reject(c, err)
+	}
+	updateUser.ID = userID
+	updateUser.ActorID = middleware.CurrentUser(c).ID
+
+	c.SetData(constant.Request, updateUser)
+
+	return nil
+}
+
+// Handle Process main logic for API.
+// @Summary Update user
+// @Description Update profile, status or password of a user. Changing status needs permission `users.block`, setting password of another user needs `users.password`
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Param id path int true "User ID"
+// @Param data body dto.UpdateUser true "User payload"
+// @Success 200 {object} response.User
+// @Failure 400 {object} response.Error
+// @Failure 422 {object} response.Error
+// @Failure 401 {object} response.Error
+// @Failure 403 {object} response.Error
+// @Failure 404 {object} response.Error
+// @Security ApiKeyAuth
+// @Router /users/{id} [put]
+func (h *UpdateUserApi) Handle(c *core.Ctx) error {
+	updateUser := c.GetData(constant.Request).(dto.UpdateUser)
+
+	user, err := services.UpdateUser(&updateUser)
+	if err != nil {
+		return errors.Render(c, err)
+	}
+
+	return c.JSON(transformers.ToUserResponse(*user))
+}
@@ -0,0 +1,13 @@
+package user
+
+import (
+	"github.com/gflydev/core"
+	"strconv"
+)
+
+// pathID get positive ID from path parameter `id`.
+func pathID(c *core.Ctx) (int, bool) {
+	id, err := strconv.Atoi(c.PathVal("id"))
+
+	return id, err == nil && id > 0
+}
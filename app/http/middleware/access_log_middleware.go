@@ -0,0 +1,159 @@
+package middleware
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/gflydev/core"
+	"github.com/gflydev/core/utils"
+	"io"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ====================================================================
+// ======================= Access Log Middleware ======================
+// ====================================================================
+
+// Access log formats of env ACCESS_LOG_FORMAT.
+const (
+	AccessLogLogfmt = "logfmt"
+	AccessLogJSON   = "json"
+	AccessLogOff    = "off"
+)
+
+// accessLogKey key of the pending access log entry in request user values.
+const accessLogKey = "__access_log__"
+
+// accessLogOutput writer of access log lines.
+var (
+	accessLogOutput io.Writer = os.Stdout
+	accessLogMutex  sync.Mutex
+)
+
+// AccessLog a global middleware to write one structured line per request with method, route pattern,
+// status, latency, bytes, user ID, client IP and request ID. Format is selected by env ACCESS_LOG_FORMAT:
+// `logfmt` (default), `json` or `off`. It must be attached after RequestID middleware.
+//
+//	r.Use(middleware.RequestID(), middleware.AccessLog())
+func AccessLog() core.MiddlewareHandler {
+	format := utils.Getenv("ACCESS_LOG_FORMAT", AccessLogLogfmt)
+
+	return func(c *core.Ctx) error {
+		if format == AccessLogOff {
+			return nil
+		}
+
+		// fasthttp closes user values implementing io.Closer after the response was produced.
+		c.Root().SetUserValue(accessLogKey, &accessEntry{
+			ctx:    c,
+			format: format,
+			start:  time.Now(),
+		})
+
+		return nil
+	}
+}
+
+// ClientIP get IP of the client. Headers `X-Forwarded-For` and `X-Real-IP` are only trusted when
+// env SERVER_TRUST_PROXY=true.
+func ClientIP(c *core.Ctx) string {
+	if utils.Getenv("SERVER_TRUST_PROXY", false) {
+		if forwarded := string(c.Root().Request.Header.Peek("X-Forwarded-For")); forwarded != "" {
+			return strings.TrimSpace(strings.Split(forwarded, ",")[0])
+		}
+		if realIP := string(c.Root().Request.Header.Peek("X-Real-IP")); net.ParseIP(realIP) != nil {
+			return realIP
+		}
+	}
+
+	return c.Root().RemoteIP().String()
+}
+
+// ====================================================================
+// ============================ Log Entry =============================
+// ====================================================================
+
+// accessEntry a pending access log line of a request.
+type accessEntry struct {
+	ctx    *core.Ctx
+	format string
+	start  time.Time
+}
+
+// accessField a key value pair of an access log line.
+type accessField struct {
+	key   string
+	value any
+}
+
+// Close write the access log line. It implements io.Closer.
+func (e *accessEntry) Close() error {
+	root := e.ctx.Root()
+
+	userID := 0
+	if user := CurrentUser(e.ctx); user != nil {
+		userID = user.ID
+	}
+
+	bytes := root.Response.Header.ContentLength()
+	if bytes < 0 {
+		bytes = len(root.Response.Body())
+	}
+
+	fields := []accessField{
+		{"time", e.start.Format(time.RFC3339)},
+		{"level", "info"},
+		{"msg", "access"},
+		{"request_id", CurrentRequestID(e.ctx)},
+		{"method", e.ctx.Method()},
+		{"route", RoutePattern(e.ctx)},
+		{"path", e.ctx.Path()},
+		{"status", root.Response.StatusCode()},
+		{"latency_ms", float64(time.Since(e.start).Microseconds()) / 1000},
+		{"bytes", bytes},
+		{"user_id", userID},
+		{"ip", ClientIP(e.ctx)},
+	}
+
+	line := formatLogfmt(fields)
+	if e.format == AccessLogJSON {
+		line = formatJSON(fields)
+	}
+
+	accessLogMutex.Lock()
+	defer accessLogMutex.Unlock()
+
+	_, err := fmt.Fprintln(accessLogOutput, line)
+
+	return err
+}
+
+// formatLogfmt format fields as `key=value` pairs. String values having spaces or quotes are quoted.
+func formatLogfmt(fields []accessField) string {
+	pairs := make([]string, 0, len(fields))
+	for _, field := range fields {
+		value := fmt.Sprint(field.value)
+		if value == "" || strings.ContainsAny(value, " \"=") {
+			value = strconv.Quote(value)
+		}
+
+		pairs = append(pairs, field.key+"="+value)
+	}
+
+	return strings.Join(pairs, " ")
+}
+
+// formatJSON format fields as a JSON object keeping their order.
+func formatJSON(fields []accessField) string {
+	pairs := make([]string, 0, len(fields))
+	for _, field := range fields {
+		value, _ := json.Marshal(field.value)
+		pairs = append(pairs, strconv.Quote(field.key)+":"+string(value))
+	}
+
+	return "{" + strings.Join(pairs, ",") + "}"
+}
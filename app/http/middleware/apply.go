@@ -0,0 +1,55 @@
+package middleware
+
+import "github.com/gflydev/core"
+
+// ====================================================================
+// ======================= Route Middlewares ==========================
+// ====================================================================
+
+// Apply attach middlewares to a single route. They run in order before the handler's validation.
+// Panics of the middlewares and the handler are recovered. The router applies it to handlers registered
+// without middlewares.
+//
+//	r.GET("/info", middleware.Apply(api.NewDefaultApi()))
+//	r.DELETE("/users/{id}", middleware.Apply(user.NewDeleteUserApi(), middleware.RequirePermissions(models.PermissionUsersDelete)))
+func Apply(handler core.IHandler, middlewares ...core.MiddlewareHandler) core.IHandler {
+	return &routeHandler{
+		IHandler:    handler,
+		middlewares: middlewares,
+	}
+}
+
+// routeHandler a handler wrapped with route middlewares.
+type routeHandler struct {
+	core.IHandler
+	middlewares []core.MiddlewareHandler
+}
+
+// Validate run route middlewares then handler's validation.
+func (h *routeHandler) Validate(c *core.Ctx) (err error) {
+	defer recoverPanic(c, &err)
+
+	for _, middleware := range h.middlewares {
+		if err = middleware(c); err != nil {
+			return err
+		}
+	}
+
+	return h.IHandler.Validate(c)
+}
+
+// Handle run handler's main logic.
+func (h *routeHandler) Handle(c *core.Ctx) (err error) {
+	defer recoverPanic(c, &err)
+
+	return h.IHandler.Handle(c)
+}
+
+// Unwrap get the handler and the route middlewares attached by Apply.
+func Unwrap(handler core.IHandler) (core.IHandler, []core.MiddlewareHandler) {
+	if h, ok := handler.(*routeHandler); ok {
+		return h.IHandler, h.middlewares
+	}
+
+	return handler, nil
+}
@@ -0,0 +1,99 @@
+package middleware
+
+import (
+	"gfly/app/constant"
+	"gfly/app/domain/models"
+	"gfly/app/domain/repository"
+	"gfly/app/errors"
+	"gfly/app/services"
+	"github.com/gflydev/core"
+	"github.com/gflydev/core/log"
+	"slices"
+	"strconv"
+)
+
+// ====================================================================
+// ========================= Auth Middlewares =========================
+// ====================================================================
+
+// Auth a middleware to require a valid bearer token for all routes of a group except given paths.
+// The authenticated user and roles are stored in `core.Ctx`. See CurrentUser and CurrentRoles.
+func Auth(excludes ...string) core.MiddlewareHandler {
+	return func(c *core.Ctx) error {
+		if slices.Contains(excludes, c.Path()) {
+			return nil
+		}
+
+		token := services.ExtractToken(c)
+		if token == "" {
+			return errors.Render(c, errors.Unauthorized("Missing bearer token"))
+		}
+
+		return authenticate(c, token)
+	}
+}
+
+// OptionalAuth a middleware for routes which are available for guests too.
+// Requests without bearer token continue as guest. An invalid token is still refused.
+func OptionalAuth() core.MiddlewareHandler {
+	return func(c *core.Ctx) error {
+		token := services.ExtractToken(c)
+		if token == "" {
+			return nil
+		}
+
+		return authenticate(c, token)
+	}
+}
+
+// authenticate validate token, load user and roles into context.
+func authenticate(c *core.Ctx, token string) error {
+	claims, err := services.ParseAccessToken(token)
+	if err != nil {
+		return errors.Render(c, err)
+	}
+
+	userID, _ := strconv.Atoi(claims.Subject)
+	user := repository.Pool.GetUserByID(userID)
+	if user == nil {
+		return errors.Render(c, services.ErrInvalidToken)
+	}
+
+	if err = services.CheckUserStatus(user); err != nil {
+		return errors.Render(c, err)
+	}
+
+	log.Tracef("Authenticated user %d", user.ID)
+
+	c.SetData(constant.User, *user)
+	c.SetData(constant.UserRoles, repository.Pool.GetRolesByUserID(user.ID))
+
+	return nil
+}
+
+// ====================================================================
+// ============================ Auth Helpers ==========================
+// ====================================================================
+
+// CurrentUser get authenticated user from context. Return nil for guests.
+func CurrentUser(c *core.Ctx) *models.User {
+	if user, ok := c.GetData(constant.User).(models.User); ok {
+		return &user
+	}
+
+	return nil
+}
+
+// CurrentRoles get roles of authenticated user from context.
+func CurrentRoles(c *core.Ctx) []models.Role {
+	if roles, ok := c.GetData(constant.UserRoles).([]models.Role); ok {
+		return roles
+	}
+
+	return []models.Role{}
+}
+
+// IsGuest check request does not belong to an authenticated user.
+func IsGuest(c *core.Ctx) bool {
+	return CurrentUser(c) == nil
+}
@@ -0,0 +1,122 @@
+package middleware
+
+import (
+	"errors"
+	"github.com/gflydev/core"
+	"github.com/gflydev/core/log"
+	"github.com/gflydev/core/utils"
+	"github.com/valyala/fasthttp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ====================================================================
+// ========================== CORS Middleware =========================
+// ====================================================================
+
+// ErrPreflightHandled stops the middleware chain after a CORS preflight request was answered.
+var ErrPreflightHandled = errors.New("CORS preflight handled")
+
+// CORS a global middleware to add Cross-Origin Resource Sharing headers for allowed origins and
+// answer preflight requests with 204. Settings are read from env:
+//   - CORS_ALLOWED_ORIGINS comma separated origins or `*`
+//   - CORS_ALLOWED_METHODS, CORS_ALLOWED_HEADERS, CORS_EXPOSED_HEADERS comma separated values
+//   - CORS_ALLOW_CREDENTIALS allow cookies and authorization headers, only for listed origins
+//   - CORS_MAX_AGE seconds to cache preflight results
+func CORS() core.MiddlewareHandler {
+	policy := newCORSPolicy()
+
+	return func(c *core.Ctx) error {
+		if policy.apply(c.Root()) {
+			c.Status(core.StatusNoContent)
+
+			return ErrPreflightHandled
+		}
+
+		return nil
+	}
+}
+
+// corsPolicy CORS settings read from env.
+type corsPolicy struct {
+	origins     []string
+	methods     string
+	headers     string
+	exposed     string
+	credentials bool
+	maxAge      string
+}
+
+// newCORSPolicy read CORS settings from env. Credentials are refused with wildcard origin, which would
+// let any website send requests with the session cookie.
+func newCORSPolicy() corsPolicy {
+	policy := corsPolicy{
+		origins:     envList("CORS_ALLOWED_ORIGINS", "*"),
+		methods:     strings.Join(envList("CORS_ALLOWED_METHODS", "GET,POST,PUT,PATCH,DELETE,OPTIONS"), ", "),
+		headers:     strings.Join(envList("CORS_ALLOWED_HEADERS", "Authorization,Content-Type,X-Request-ID,X-API-Key,X-CSRF-Token"), ", "),
+		exposed:     strings.Join(envList("CORS_EXPOSED_HEADERS", "X-Request-ID,X-RateLimit-Limit,X-RateLimit-Remaining,X-RateLimit-Reset,Retry-After"), ", "),
+		credentials: utils.Getenv("CORS_ALLOW_CREDENTIALS", false),
+		maxAge:      strconv.Itoa(utils.Getenv("CORS_MAX_AGE", 600)),
+	}
+
+	if policy.credentials && slices.Contains(policy.origins, "*") {
+		log.Warnf("CORS_ALLOW_CREDENTIALS is ignored with CORS_ALLOWED_ORIGINS=*, list the allowed origins instead")
+		policy.credentials = false
+	}
+
+	return policy
+}
+
+// apply set CORS response headers of a request from an allowed origin.
+// Return true for a preflight request, which must be answered without running the handler.
+func (p corsPolicy) apply(root *fasthttp.RequestCtx) bool {
+	header := &root.Response.Header
+	header.Set("Vary", "Origin")
+
+	origin := string(root.Request.Header.Peek("Origin"))
+	if origin == "" {
+		return false
+	}
+
+	wildcard := slices.Contains(p.origins, "*")
+	if !wildcard && !slices.Contains(p.origins, origin) {
+		return false
+	}
+
+	// Any origin never gets credentials
+	if wildcard {
+		header.Set("Access-Control-Allow-Origin", "*")
+	} else {
+		header.Set("Access-Control-Allow-Origin", origin)
+	}
+	if p.credentials && !wildcard {
+		header.Set("Access-Control-Allow-Credentials", "true")
+	}
+	if p.exposed != "" {
+		header.Set("Access-Control-Expose-Headers", p.exposed)
+	}
+
+	// Preflight request
+	if string(root.Method()) == "OPTIONS" && len(root.Request.Header.Peek("Access-Control-Request-Method")) > 0 {
+		header.Set("Access-Control-Allow-Methods", p.methods)
+		header.Set("Access-Control-Allow-Headers", p.headers)
+		header.Set("Access-Control-Max-Age", p.maxAge)
+
+		return true
+	}
+
+	return false
+}
+
+// envList get comma separated values of an env variable.
+func envList(key, def string) []string {
+	var values []string
+	for _, value := range strings.Split(utils.Getenv(key, def), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+
+	return values
+}
@@ -0,0 +1,147 @@
+package middleware
+
+import (
+	"testing"
+)
+
+func TestCORSPolicy(t *testing.T) {
+	restricted := corsPolicy{
+		origins:     []string{"https://gfly.dev"},
+		methods:     "GET, POST",
+		headers:     "Authorization",
+		exposed:     "X-Request-ID",
+		credentials: true,
+		maxAge:      "600",
+	}
+	wildcard := corsPolicy{
+		origins: []string{"*"},
+		methods: "GET",
+		headers: "Authorization",
+		maxAge:  "600",
+	}
+
+	tests := []struct {
+		name          string
+		policy        corsPolicy
+		method        string
+		headers       map[string]string
+		wantPreflight bool
+		wantHeaders   map[string]string
+	}{
+		{
+			name:        "no origin",
+			policy:      restricted,
+			method:      "GET",
+			wantHeaders: map[string]string{"Vary": "Origin", "Access-Control-Allow-Origin": ""},
+		},
+		{
+			name:        "origin not allowed",
+			policy:      restricted,
+			method:      "GET",
+			headers:     map[string]string{"Origin": "https://evil.dev"},
+			wantHeaders: map[string]string{"Access-Control-Allow-Origin": "", "Access-Control-Allow-Credentials": ""},
+		},
+		{
+			name:    "allowed origin with credentials",
+			policy:  restricted,
+			method:  "GET",
+			headers: map[string]string{"Origin": "https://gfly.dev"},
+			wantHeaders: map[string]string{
+				"Access-Control-Allow-Origin":      "https://gfly.dev",
+				"Access-Control-Allow-Credentials": "true",
+				"Access-Control-Expose-Headers":    "X-Request-ID",
+				"Access-Control-Allow-Methods":     "",
+			},
+		},
+		{
+			name:        "wildcard without credentials",
+			policy:      wildcard,
+			method:      "GET",
+			headers:     map[string]string{"Origin": "https://any.dev"},
+			wantHeaders: map[string]string{"Access-Control-Allow-Origin": "*", "Access-Control-Expose-Headers": ""},
+		},
+		{
+			name: "wildcard with credentials sends no credentials",
+			policy: corsPolicy{
+				origins:     []string{"*"},
+				credentials: true,
+			},
+			method:  "GET",
+			headers: map[string]string{"Origin": "https://any.dev"},
+			wantHeaders: map[string]string{
+				"Access-Control-Allow-Origin":      "*",
+				"Access-Control-Allow-Credentials": "",
+			},
+		},
+		{
+			name:          "preflight",
+			policy:        restricted,
+			method:        "OPTIONS",
+			headers:       map[string]string{"Origin": "https://gfly.dev", "Access-Control-Request-Method": "POST"},
+			wantPreflight: true,
+			wantHeaders: map[string]string{
+				"Access-Control-Allow-Methods": "GET, POST",
+				"Access-Control-Allow-Headers": "Authorization",
+				"Access-Control-Max-Age":       "600",
+			},
+		},
+		{
+			name:        "options without request method is not a preflight",
+			policy:      restricted,
+			method:      "OPTIONS",
+			headers:     map[string]string{"Origin": "https://gfly.dev"},
+			wantHeaders: map[string]string{"Access-Control-Allow-Methods": ""},
+		},
+		{
+			name:        "preflight from origin not allowed",
+			policy:      restricted,
+			method:      "OPTIONS",
+			headers:     map[string]string{"Origin": "https://evil.dev", "Access-Control-Request-Method": "POST"},
+			wantHeaders: map[string]string{"Access-Control-Allow-Methods": ""},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := newRequest(tt.method, "", tt.headers)
+
+			if preflight := tt.policy.apply(root); preflight != tt.wantPreflight {
+				t.Fatalf("expected preflight=%v, got %v", tt.wantPreflight, preflight)
+			}
+
+			for key, want := range tt.wantHeaders {
+				if got := string(root.Response.Header.Peek(key)); got != want {
+					t.Fatalf("expected header %s=%q, got %q", key, want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestNewCORSPolicyRefusesWildcardCredentials(t *testing.T) {
+	t.Setenv("CORS_ALLOWED_ORIGINS", "https://gfly.dev,*")
+	t.Setenv("CORS_ALLOW_CREDENTIALS", "true")
+
+	if newCORSPolicy().credentials {
+		t.Fatal("expected credentials to be disabled with wildcard origin")
+	}
+
+	t.Setenv("CORS_ALLOWED_ORIGINS", "https://gfly.dev")
+
+	if !newCORSPolicy().credentials {
+		t.Fatal("expected credentials to be allowed for listed origins")
+	}
+}
+
+func TestEnvList(t *testing.T) {
+	t.Setenv("CORS_TEST_LIST", " https://a.dev, ,https://b.dev ")
+
+	got := envList("CORS_TEST_LIST", "*")
+	if len(got) != 2 || got[0] != "https://a.dev" || got[1] != "https://b.dev" {
+		t.Fatalf("unexpected values %v", got)
+	}
+
+	if got = envList("CORS_TEST_UNSET", "*"); len(got) != 1 || got[0] != "*" {
+		t.Fatalf("expected default value, got %v", got)
+	}
+}
@@ -0,0 +1,108 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"gfly/app/constant"
+	"gfly/app/errors"
+	"github.com/flosch/pongo2/v6"
+	"github.com/gflydev/core"
+	"github.com/valyala/fasthttp"
+	"html"
+	"slices"
+	"strings"
+)
+
+// ====================================================================
+// ========================== CSRF Middleware =========================
+// ====================================================================
+
+// CSRFField name of the form field carrying CSRF token.
+const CSRFField = "_token"
+
+// ErrCSRFMismatch CSRF token of a request is missing or invalid.
+var ErrCSRFMismatch = errors.Forbidden("CSRF token mismatch").WithCode(constant.ErrCodeCSRFMismatch)
+
+// safeMethods HTTP methods which do not need CSRF token.
+var safeMethods = []string{"GET", "HEAD", "OPTIONS", "TRACE"}
+
+// Register template helper `csrf_field`.
+//
+//	<form method="post">
+//		{{ csrf_field(csrf_token) }}
+//	</form>
+func init() {
+	pongo2.Globals["csrf_field"] = func(token string) *pongo2.Value {
+		return pongo2.AsSafeValue(fmt.Sprintf(
+			`<input type="hidden" name="%s" value="%s">`,
+			CSRFField, html.EscapeString(token),
+		))
+	}
+}
+
+// CSRF a global middleware to protect web form posts by a token kept in session. Requests with unsafe
+// methods must send the token in form field `_token` or header `X-CSRF-Token`, otherwise they get 403.
+// Paths having one of `excludes` prefixes are skipped, like API routes which use bearer tokens.
+// Pass the token to templates with CSRFToken.
+//
+//	return c.View("profile", core.Data{"csrf_token": middleware.CSRFToken(c)})
+func CSRF(excludes ...string) core.MiddlewareHandler {
+	return func(c *core.Ctx) error {
+		path := c.Path()
+		if slices.ContainsFunc(excludes, func(prefix string) bool {
+			return strings.HasPrefix(path, prefix)
+		}) {
+			return nil
+		}
+
+		token, _ := c.GetSession(constant.SessionCSRFToken).(string)
+		if token == "" {
+			token = newCSRFToken()
+			c.SetSession(constant.SessionCSRFToken, token)
+		}
+		c.SetData(constant.CSRFToken, token)
+
+		if err := verifyCSRF(c.Root(), token); err != nil {
+			return errors.Render(c, err)
+		}
+
+		return nil
+	}
+}
+
+// verifyCSRF check a request with unsafe method sends given session token. Return ErrCSRFMismatch otherwise.
+func verifyCSRF(root *fasthttp.RequestCtx, token string) error {
+	if slices.Contains(safeMethods, string(root.Method())) {
+		return nil
+	}
+
+	sent := string(root.FormValue(CSRFField))
+	if sent == "" {
+		sent = string(root.Request.Header.Peek(constant.HeaderCSRFToken))
+	}
+
+	if token == "" || subtle.ConstantTimeCompare([]byte(sent), []byte(token)) != 1 {
+		return ErrCSRFMismatch
+	}
+
+	return nil
+}
+
+// CSRFToken get CSRF token of current session.
+func CSRFToken(c *core.Ctx) string {
+	if token, ok := c.GetData(constant.CSRFToken).(string); ok {
+		return token
+	}
+
+	return ""
+}
+
+// newCSRFToken generate a random CSRF token.
+func newCSRFToken() string {
+	b := make([]byte, 32)
+	_, _ = rand.Read(b)
+
+	return hex.EncodeToString(b)
+}
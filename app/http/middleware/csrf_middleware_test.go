@@ -0,0 +1,98 @@
+package middleware
+
+import (
+	"gfly/app/constant"
+	"github.com/gflydev/core"
+	"github.com/valyala/fasthttp"
+	"testing"
+)
+
+// newRequest create a request context with given method, form body and headers.
+func newRequest(method, body string, headers map[string]string) *fasthttp.RequestCtx {
+	root := &fasthttp.RequestCtx{}
+	root.Request.Header.SetMethod(method)
+	root.Request.SetRequestURI("/profile")
+	if body != "" {
+		root.Request.Header.SetContentType("application/x-www-form-urlencoded")
+		root.Request.SetBodyString(body)
+	}
+	for key, value := range headers {
+		root.Request.Header.Set(key, value)
+	}
+
+	return root
+}
+
+func TestVerifyCSRF(t *testing.T) {
+	const token = "0123456789abcdef"
+
+	tests := []struct {
+		name    string
+		method  string
+		body    string
+		headers map[string]string
+		token   string
+		want    error
+	}{
+		{"safe method without token", "GET", "", nil, token, nil},
+		{"head without token", "HEAD", "", nil, token, nil},
+		{"form field", "POST", CSRFField + "=" + token, nil, token, nil},
+		{"header", "DELETE", "", map[string]string{constant.HeaderCSRFToken: token}, token, nil},
+		{"missing token", "POST", "name=John", nil, token, ErrCSRFMismatch},
+		{"wrong form field", "POST", CSRFField + "=fedcba9876543210", nil, token, ErrCSRFMismatch},
+		{"wrong header", "PUT", "", map[string]string{constant.HeaderCSRFToken: "x"}, token, ErrCSRFMismatch},
+		{"prefix of token", "POST", CSRFField + "=01234567", nil, token, ErrCSRFMismatch},
+		{"empty session token", "POST", CSRFField + "=", nil, "", ErrCSRFMismatch},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := verifyCSRF(newRequest(tt.method, tt.body, tt.headers), tt.token); err != tt.want {
+				t.Fatalf("expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+// spyHandler a handler recording whether it was reached.
+type spyHandler struct {
+	core.Api
+	reached bool
+}
+
+// Validate implement core.IHandler.
+func (h *spyHandler) Validate(_ *core.Ctx) error {
+	h.reached = true
+
+	return nil
+}
+
+func TestCSRFMismatchStopsChain(t *testing.T) {
+	tests := []struct {
+		name    string
+		root    *fasthttp.RequestCtx
+		reached bool
+	}{
+		{"matching web post", newRequest("POST", CSRFField+"=secret", nil), true},
+		{"mismatched web post", newRequest("POST", CSRFField+"=forged", nil), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := &spyHandler{}
+
+			// Same check as CSRF, the rendered error is returned to the chain
+			csrf := func(_ *core.Ctx) error {
+				return verifyCSRF(tt.root, "secret")
+			}
+
+			err := Apply(handler, csrf).Validate(nil)
+			if handler.reached != tt.reached {
+				t.Fatalf("expected handler reached=%v, got %v (err %v)", tt.reached, handler.reached, err)
+			}
+			if !tt.reached && err == nil {
+				t.Fatal("expected an error stopping the chain")
+			}
+		})
+	}
+}
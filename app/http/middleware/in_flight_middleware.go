@@ -0,0 +1,74 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"gfly/app/services"
+	"github.com/gflydev/core"
+	"sync/atomic"
+	"time"
+)
+
+// ====================================================================
+// ======================== In-flight Middleware ======================
+// ====================================================================
+
+// inFlightKey key of the pending in-flight marker in request user values.
+const inFlightKey = "__in_flight__"
+
+// inFlightPollInterval interval to check in-flight requests while waiting for them.
+const inFlightPollInterval = 50 * time.Millisecond
+
+// inFlightSize number of requests being processed.
+var inFlightSize atomic.Int64
+
+// InFlight a global middleware to count requests being processed, so graceful shutdown can wait for
+// them. See WaitInFlight.
+//
+//	r.Use(middleware.InFlight())
+func InFlight() core.MiddlewareHandler {
+	return func(c *core.Ctx) error {
+		inFlightSize.Add(1)
+
+		// Move keep-alive clients to other instances while shutting down
+		if services.IsShuttingDown() {
+			c.Root().SetConnectionClose()
+		}
+
+		// fasthttp closes user values implementing io.Closer after the response was produced.
+		c.Root().SetUserValue(inFlightKey, inFlightMarker{})
+
+		return nil
+	}
+}
+
+// InFlightRequests get number of requests being processed.
+func InFlightRequests() int64 {
+	return inFlightSize.Load()
+}
+
+// WaitInFlight wait for requests being processed to complete, or until `ctx` is done.
+func WaitInFlight(ctx context.Context) error {
+	ticker := time.NewTicker(inFlightPollInterval)
+	defer ticker.Stop()
+
+	for InFlightRequests() > 0 {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return fmt.Errorf("%d requests still in flight: %w", InFlightRequests(), ctx.Err())
+		}
+	}
+
+	return nil
+}
+
+// inFlightMarker release an in-flight request. It implements io.Closer.
+type inFlightMarker struct{}
+
+// Close implement io.Closer.
+func (inFlightMarker) Close() error {
+	inFlightSize.Add(-1)
+
+	return nil
+}
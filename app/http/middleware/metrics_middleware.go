@@ -0,0 +1,54 @@
+package middleware
+
+import (
+	"gfly/app/metrics"
+	"github.com/gflydev/core"
+	"time"
+)
+
+// ====================================================================
+// ========================= Metrics Middleware =======================
+// ====================================================================
+
+// metricsKey key of the pending request observation in request user values.
+const metricsKey = "__metrics__"
+
+// Metrics a global middleware to count requests and observe their latency per route pattern and status.
+// Disabled when env METRICS_ENABLED=false.
+//
+//	r.Use(middleware.Metrics())
+func Metrics() core.MiddlewareHandler {
+	enabled := metrics.Enabled()
+
+	return func(c *core.Ctx) error {
+		if !enabled {
+			return nil
+		}
+
+		// fasthttp closes user values implementing io.Closer after the response was produced.
+		c.Root().SetUserValue(metricsKey, &requestObservation{
+			ctx:   c,
+			start: time.Now(),
+		})
+
+		return nil
+	}
+}
+
+// requestObservation a pending observation of a request.
+type requestObservation struct {
+	ctx   *core.Ctx
+	start time.Time
+}
+
+// Close record the request. It implements io.Closer.
+func (o *requestObservation) Close() error {
+	metrics.ObserveRequest(
+		o.ctx.Method(),
+		RoutePattern(o.ctx),
+		o.ctx.Root().Response.StatusCode(),
+		time.Since(o.start),
+	)
+
+	return nil
+}
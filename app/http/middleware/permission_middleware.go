@@ -0,0 +1,40 @@
+package middleware
+
+import (
+	"gfly/app/domain/models"
+	"gfly/app/errors"
+	"gfly/app/services"
+	"github.com/gflydev/core"
+)
+
+// ====================================================================
+// ===================== Permission Middlewares =======================
+// ====================================================================
+
+// RequirePermissions a middleware for API routes to allow users having all given permission slugs.
+// It must be attached after Auth middleware. Refused requests get 403 error.
+//
+//	r.DELETE("/{id}", middleware.Apply(user.NewDeleteUserApi(), middleware.RequirePermissions(models.PermissionUsersDelete)))
+func RequirePermissions(permissions ...string) core.MiddlewareHandler {
+	return func(c *core.Ctx) error {
+		user := CurrentUser(c)
+		if user == nil {
+			return errors.Render(c, errors.ErrUnauthorized)
+		}
+
+		if err := services.Authorize(user.ID, permissions...); err != nil {
+			return errors.Render(c, err)
+		}
+
+		return nil
+	}
+}
+
+// ====================================================================
+// ========================= Permission Helpers =======================
+// ====================================================================
+
+// HasAllPermissions check given user permissions contain all required permission slugs.
+func HasAllPermissions(userPermissions []models.Permission, required ...string) bool {
+	return len(services.MissingPermissions(userPermissions, required...)) == 0
+}
@@ -0,0 +1,108 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"gfly/app/constant"
+	"gfly/app/errors"
+	"gfly/app/services"
+	"github.com/gflydev/core"
+	"github.com/gflydev/core/utils"
+	"math"
+	"strconv"
+	"time"
+)
+
+// ====================================================================
+// ====================== Rate Limit Middleware =======================
+// ====================================================================
+
+// RateKeyFunc get bucket key of a request.
+type RateKeyFunc func(c *core.Ctx) string
+
+// Rate allowed number of requests per window.
+type Rate struct {
+	Limit  int
+	Window time.Duration
+}
+
+// PerSecond allow `limit` requests per second.
+func PerSecond(limit int) Rate {
+	return Rate{Limit: limit, Window: time.Second}
+}
+
+// PerMinute allow `limit` requests per minute.
+func PerMinute(limit int) Rate {
+	return Rate{Limit: limit, Window: time.Minute}
+}
+
+// PerHour allow `limit` requests per hour.
+func PerHour(limit int) Rate {
+	return Rate{Limit: limit, Window: time.Hour}
+}
+
+// RateLimit a middleware to limit requests of each client to given rate in a sliding window.
+// Buckets are separated by policy `name` and keyed by `keyBy` (default ByIP). Every response gets
+// headers `X-RateLimit-Limit`, `X-RateLimit-Remaining` and `X-RateLimit-Reset` (seconds).
+// Refused requests get 429 with header `Retry-After`. Set env RATE_LIMIT_ENABLED=false to disable.
+//
+//	r.POST("/signin", middleware.Apply(auth.NewSignInApi(), middleware.RateLimit("signin", middleware.PerMinute(5))))
+//
+//	r.Group("/users", func(r *routes.Group) {
+//		r.Use(middleware.RateLimit("users", middleware.PerMinute(600), middleware.ByUser))
+//	})
+func RateLimit(name string, rate Rate, keyBy ...RateKeyFunc) core.MiddlewareHandler {
+	key := ByIP
+	if len(keyBy) > 0 {
+		key = keyBy[0]
+	}
+
+	return func(c *core.Ctx) error {
+		if !utils.Getenv("RATE_LIMIT_ENABLED", true) {
+			return nil
+		}
+
+		result := services.HitRateLimit(name, key(c), rate.Limit, rate.Window)
+		reset := strconv.Itoa(int(math.Ceil(result.ResetAfter.Seconds())))
+
+		c.SetHeader("X-RateLimit-Limit", strconv.Itoa(result.Limit))
+		c.SetHeader("X-RateLimit-Remaining", strconv.Itoa(result.Remaining))
+		c.SetHeader("X-RateLimit-Reset", reset)
+
+		if !result.Allowed {
+			c.SetHeader("Retry-After", reset)
+
+			return errors.Render(c, errors.ErrRateLimited)
+		}
+
+		return nil
+	}
+}
+
+// ByIP key requests by client IP.
+func ByIP(c *core.Ctx) string {
+	return "ip:" + ClientIP(c)
+}
+
+// ByUser key requests by authenticated user, or by client IP for guests.
+// It must be attached after Auth middleware.
+func ByUser(c *core.Ctx) string {
+	if user := CurrentUser(c); user != nil {
+		return "user:" + strconv.Itoa(user.ID)
+	}
+
+	return ByIP(c)
+}
+
+// ByAPIKey key requests by header `X-API-Key`, or by client IP without the header.
+// The key is hashed, so it is never stored in plain text.
+func ByAPIKey(c *core.Ctx) string {
+	apiKey := c.Root().Request.Header.Peek(constant.HeaderAPIKey)
+	if len(apiKey) == 0 {
+		return ByIP(c)
+	}
+
+	sum := sha256.Sum256(apiKey)
+
+	return "key:" + hex.EncodeToString(sum[:8])
+}
@@ -0,0 +1,68 @@
+package middleware
+
+import (
+	"fmt"
+	"gfly/app/errors"
+	"github.com/gflydev/core"
+	"github.com/gflydev/core/log"
+	"runtime/debug"
+	"time"
+)
+
+// ====================================================================
+// ======================== Recovery Middleware =======================
+// ====================================================================
+
+// Recover wrap a middleware so its panics are recovered like the ones of handlers. The router of
+// `app/http/routes` wraps global, group and route middlewares with it.
+//
+//	r.Use(middleware.Recover(middleware.Auth()))
+func Recover(middleware core.MiddlewareHandler) core.MiddlewareHandler {
+	return func(c *core.Ctx) (err error) {
+		defer recoverPanic(c, &err)
+
+		return middleware(c)
+	}
+}
+
+// recoverPanic recover a panic of a handler or a middleware. Use it in a deferred call of a function
+// having named result `err`.
+//
+//	func (h *routeHandler) Handle(c *core.Ctx) (err error) {
+//		defer recoverPanic(c, &err)
+//		return h.IHandler.Handle(c)
+//	}
+func recoverPanic(c *core.Ctx, err *error) {
+	if recovered := recover(); recovered != nil {
+		*err = handlePanic(c, recovered)
+	}
+}
+
+// handlePanic handle a recovered panic, replaced in tests.
+var handlePanic = reportPanic
+
+// reportPanic log the stack of a recovered panic with the request ID and forward it to crash reporters,
+// then respond a 500 error.
+func reportPanic(c *core.Ctx, recovered any) error {
+	requestID := CurrentRequestID(c)
+	stack := string(debug.Stack())
+
+	log.Errorf("[%s] Panic recovered: %v\n%s", requestID, recovered, stack)
+
+	userID := 0
+	if user := CurrentUser(c); user != nil {
+		userID = user.ID
+	}
+
+	errors.Report(errors.CrashReport{
+		Time:      time.Now(),
+		RequestID: requestID,
+		Method:    c.Method(),
+		Path:      c.Path(),
+		UserID:    userID,
+		Panic:     fmt.Sprint(recovered),
+		Stack:     stack,
+	})
+
+	return errors.Render(c, errors.Internal(fmt.Errorf("panic: %v", recovered)))
+}
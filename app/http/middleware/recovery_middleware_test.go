@@ -0,0 +1,67 @@
+package middleware
+
+import (
+	"errors"
+	"github.com/gflydev/core"
+	"testing"
+)
+
+// errPanic error answered for recovered panics in tests.
+var errPanic = errors.New("panic recovered")
+
+// stubPanics replace the panic handler for the duration of a test.
+func stubPanics(t *testing.T) *[]any {
+	t.Helper()
+
+	var recovered []any
+	original := handlePanic
+	handlePanic = func(_ *core.Ctx, value any) error {
+		recovered = append(recovered, value)
+
+		return errPanic
+	}
+	t.Cleanup(func() {
+		handlePanic = original
+	})
+
+	return &recovered
+}
+
+func TestRecover(t *testing.T) {
+	recovered := stubPanics(t)
+
+	errDenied := errors.New("denied")
+	tests := []struct {
+		name       string
+		middleware core.MiddlewareHandler
+		want       error
+	}{
+		{"passes", func(_ *core.Ctx) error { return nil }, nil},
+		{"returns an error", func(_ *core.Ctx) error { return errDenied }, errDenied},
+		{"panics", func(_ *core.Ctx) error { panic("boom") }, errPanic},
+	}
+
+	for _, tt := range tests {
+		if err := Recover(tt.middleware)(nil); err != tt.want {
+			t.Fatalf("%s: expected %v, got %v", tt.name, tt.want, err)
+		}
+	}
+
+	if len(*recovered) != 1 || (*recovered)[0] != "boom" {
+		t.Fatalf("expected one recovered panic boom, got %v", *recovered)
+	}
+}
+
+func TestApplyRecoversRouteMiddlewarePanic(t *testing.T) {
+	stubPanics(t)
+
+	handler := &spyHandler{}
+	err := Apply(handler, func(_ *core.Ctx) error { panic("boom") }).Validate(nil)
+
+	if err != errPanic {
+		t.Fatalf("expected %v, got %v", errPanic, err)
+	}
+	if handler.reached {
+		t.Fatal("expected handler not to be reached after a panic")
+	}
+}
@@ -0,0 +1,51 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"gfly/app/constant"
+	"github.com/gflydev/core"
+	"regexp"
+)
+
+// ====================================================================
+// ======================= Request ID Middleware ======================
+// ====================================================================
+
+// requestIDPattern format of request IDs accepted from clients.
+var requestIDPattern = regexp.MustCompile(`^[A-Za-z0-9._:-]{8,128}$`)
+
+// RequestID a global middleware to accept header `X-Request-ID` from clients or generate a new ID.
+// The ID is stored in `core.Ctx` and echoed in the response header. See CurrentRequestID.
+//
+//	r.Use(middleware.RequestID())
+func RequestID() core.MiddlewareHandler {
+	return func(c *core.Ctx) error {
+		requestID := string(c.Root().Request.Header.Peek(constant.HeaderRequestID))
+		if !requestIDPattern.MatchString(requestID) {
+			requestID = newRequestID()
+		}
+
+		c.SetData(constant.RequestID, requestID)
+		c.SetHeader(constant.HeaderRequestID, requestID)
+
+		return nil
+	}
+}
+
+// CurrentRequestID get ID of current request from context.
+func CurrentRequestID(c *core.Ctx) string {
+	if requestID, ok := c.GetData(constant.RequestID).(string); ok {
+		return requestID
+	}
+
+	return ""
+}
+
+// newRequestID generate a random request ID.
+func newRequestID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+
+	return hex.EncodeToString(b)
+}
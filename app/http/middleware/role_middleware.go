@@ -0,0 +1,100 @@
+package middleware
+
+import (
+	stdErrors "errors"
+	"fmt"
+	"gfly/app/constant"
+	"gfly/app/domain/models"
+	"gfly/app/domain/repository"
+	"gfly/app/errors"
+	"github.com/gflydev/core"
+	"github.com/gflydev/core/utils"
+	"net/url"
+	"slices"
+	"strconv"
+)
+
+// ====================================================================
+// ======================== Role Middlewares ==========================
+// ====================================================================
+
+// RequireRoles a middleware for API routes to allow users having any of given roles.
+// It must be attached after Auth middleware. Refused requests get 403 error.
+//
+//	r.GET("/reports", middleware.Apply(api.NewReportsApi(), middleware.RequireRoles(models.RoleAdmin, models.RoleModerator)))
+func RequireRoles(roles ...models.RoleType) core.MiddlewareHandler {
+	return func(c *core.Ctx) error {
+		if IsGuest(c) {
+			return errors.Render(c, errors.ErrUnauthorized)
+		}
+
+		if !HasAnyRole(CurrentRoles(c), roles...) {
+			return errors.Render(c, errors.ErrForbidden.WithDetails(core.Data{
+				"roles": roleNames(roles),
+			}))
+		}
+
+		return nil
+	}
+}
+
+// ErrLoginRedirect stops the middleware chain after a guest was redirected to the login page.
+var ErrLoginRedirect = stdErrors.New("redirected to login page")
+
+// RequireRolesPage a middleware for Web routes to allow users having any of given roles.
+// The user is taken from context or from session key `constant.SessionUserID`.
+// Refused requests are redirected to login page `LOGIN_URL` with the requested path.
+//
+//	r.GET("/admin", middleware.Apply(page.NewAdminPage(), middleware.RequireRolesPage(models.RoleAdmin)))
+func RequireRolesPage(roles ...models.RoleType) core.MiddlewareHandler {
+	return func(c *core.Ctx) error {
+		userRoles := CurrentRoles(c)
+
+		if IsGuest(c) {
+			userID, _ := strconv.Atoi(fmt.Sprint(c.GetSession(constant.SessionUserID)))
+			if userID > 0 {
+				userRoles = repository.Pool.GetRolesByUserID(userID)
+			}
+		}
+
+		if HasAnyRole(userRoles, roles...) {
+			return nil
+		}
+
+		loginURL := fmt.Sprintf(
+			"%s?redirect=%s",
+			utils.Getenv("LOGIN_URL", "/login"),
+			url.QueryEscape(c.Path()),
+		)
+		if err := c.Redirect(loginURL); err != nil {
+			return err
+		}
+
+		return ErrLoginRedirect
+	}
+}
+
+// ====================================================================
+// ============================ Role Helpers ==========================
+// ====================================================================
+
+// HasAnyRole check given user roles contain any of required roles.
+func HasAnyRole(userRoles []models.Role, required ...models.RoleType) bool {
+	for _, role := range userRoles {
+		if slices.Contains(roleNames(required), role.Slug) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// roleNames get slugs of given role types.
+func roleNames(roles []models.RoleType) []string {
+	names := make([]string, 0, len(roles))
+	for _, role := range roles {
+		names = append(names, role.Name())
+	}
+
+	return names
+}
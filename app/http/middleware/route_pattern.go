@@ -0,0 +1,83 @@
+package middleware
+
+import (
+	"github.com/gflydev/core"
+	"strings"
+	"sync/atomic"
+)
+
+// ====================================================================
+// =========================== Route Pattern ==========================
+// ====================================================================
+
+// UnmatchedRoute route pattern of requests matching no mounted route, like 404 of scanners.
+const UnmatchedRoute = "unmatched"
+
+// routePatterns patterns of mounted routes split into path segments.
+var routePatterns atomic.Pointer[[][]string]
+
+// SetRoutePatterns set patterns of mounted routes, like `/api/v1/users/{id}`. The router of
+// `app/http/routes` sets them when it mounts routes.
+func SetRoutePatterns(patterns []string) {
+	split := make([][]string, 0, len(patterns))
+	for _, pattern := range patterns {
+		split = append(split, strings.Split(pattern, "/"))
+	}
+
+	routePatterns.Store(&split)
+}
+
+// RoutePattern get the mounted route pattern matching path of current request, or UnmatchedRoute.
+// Metrics, access logs and spans are labeled by it, so their cardinality is bounded by the routes.
+func RoutePattern(c *core.Ctx) string {
+	return matchRoutePattern(c.Path())
+}
+
+// matchRoutePattern get the route pattern matching path. Static segments win over parameters, so
+// `/users/me` is preferred to `/users/{id}`.
+func matchRoutePattern(path string) string {
+	patterns := routePatterns.Load()
+	if patterns == nil {
+		return UnmatchedRoute
+	}
+
+	segments := strings.Split(path, "/")
+	best, bestScore := UnmatchedRoute, -1
+
+	for _, pattern := range *patterns {
+		if score := matchSegments(pattern, segments); score > bestScore {
+			best, bestScore = strings.Join(pattern, "/"), score
+		}
+	}
+
+	return best
+}
+
+// matchSegments get number of static segments of pattern matching path segments, or -1 when it does
+// not match. A parameter `{name}` matches one segment, a catch-all `{name:*}` matches the rest.
+func matchSegments(pattern, segments []string) int {
+	score := 0
+	for i, part := range pattern {
+		isParam := strings.HasPrefix(part, "{") && strings.HasSuffix(part, "}")
+		if isParam && strings.HasSuffix(part, ":*}") {
+			return score
+		}
+		if i >= len(segments) {
+			return -1
+		}
+
+		switch {
+		case isParam && segments[i] != "":
+		case part == segments[i]:
+			score++
+		default:
+			return -1
+		}
+	}
+
+	if len(segments) != len(pattern) {
+		return -1
+	}
+
+	return score
+}
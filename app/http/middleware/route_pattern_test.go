@@ -0,0 +1,46 @@
+package middleware
+
+import "testing"
+
+func TestMatchRoutePattern(t *testing.T) {
+	SetRoutePatterns([]string{
+		"/",
+		"/api/v1/users",
+		"/api/v1/users/{id}",
+		"/api/v1/users/me",
+		"/api/v1/users/{id}/addresses/{address_id}",
+		"/assets/{filepath:*}",
+	})
+	t.Cleanup(func() {
+		routePatterns.Store(nil)
+	})
+
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"/", "/"},
+		{"/api/v1/users", "/api/v1/users"},
+		{"/api/v1/users/12", "/api/v1/users/{id}"},
+		{"/api/v1/users/john-doe", "/api/v1/users/{id}"},
+		{"/api/v1/users/me", "/api/v1/users/me"},
+		{"/api/v1/users/12/addresses/3", "/api/v1/users/{id}/addresses/{address_id}"},
+		{"/assets/css/app.css", "/assets/{filepath:*}"},
+		{"/api/v1/users/", UnmatchedRoute},
+		{"/api/v1/users/12/orders", UnmatchedRoute},
+		{"/wp-login.php", UnmatchedRoute},
+		{"/.env", UnmatchedRoute},
+	}
+
+	for _, tt := range tests {
+		if got := matchRoutePattern(tt.path); got != tt.want {
+			t.Fatalf("%s: expected %s, got %s", tt.path, tt.want, got)
+		}
+	}
+}
+
+func TestMatchRoutePatternWithoutRoutes(t *testing.T) {
+	if got := matchRoutePattern("/api/v1/users"); got != UnmatchedRoute {
+		t.Fatalf("expected %s before routes are mounted, got %s", UnmatchedRoute, got)
+	}
+}
@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"github.com/gflydev/core"
+	"github.com/gflydev/core/utils"
+	"strconv"
+)
+
+// ====================================================================
+// ==================== Security Headers Middleware ===================
+// ====================================================================
+
+// defaultCSP default Content-Security-Policy. Inline styles & scripts are allowed for the API docs page.
+const defaultCSP = "default-src 'self'; img-src 'self' data:; style-src 'self' 'unsafe-inline'; " +
+	"script-src 'self' 'unsafe-inline'; frame-ancestors 'none'; base-uri 'self'; form-action 'self'"
+
+// SecurityHeaders a global middleware to add security headers to all responses.
+// HSTS is only sent when TLS is configured by env SERVER_TLS_CERT. Values are read from env
+// SECURITY_CSP, SECURITY_FRAME_OPTIONS, SECURITY_REFERRER_POLICY and SECURITY_HSTS_MAX_AGE.
+// Set a header value to `off` to omit it.
+func SecurityHeaders() core.MiddlewareHandler {
+	csp := utils.Getenv("SECURITY_CSP", "")
+	if csp == "" {
+		csp = defaultCSP
+	}
+
+	headers := map[string]string{
+		"Content-Security-Policy": csp,
+		"X-Frame-Options":         utils.Getenv("SECURITY_FRAME_OPTIONS", "DENY"),
+		"Referrer-Policy":         utils.Getenv("SECURITY_REFERRER_POLICY", "strict-origin-when-cross-origin"),
+		"X-Content-Type-Options":  "nosniff",
+	}
+
+	if utils.Getenv("SERVER_TLS_CERT", "") != "" {
+		headers["Strict-Transport-Security"] = "max-age=" +
+			strconv.Itoa(utils.Getenv("SECURITY_HSTS_MAX_AGE", 31536000)) + "; includeSubDomains"
+	}
+
+	return func(c *core.Ctx) error {
+		for key, value := range headers {
+			if value != "" && value != "off" {
+				c.SetHeader(key, value)
+			}
+		}
+
+		return nil
+	}
+}
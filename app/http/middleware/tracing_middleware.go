@@ -0,0 +1,84 @@
+package middleware
+
+import (
+	"context"
+	"gfly/app/constant"
+	"gfly/app/tracing"
+	"github.com/gflydev/core"
+	"github.com/valyala/fasthttp"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ====================================================================
+// ========================= Tracing Middleware =======================
+// ====================================================================
+
+// tracingKey key of the pending request span in request user values.
+const tracingKey = "__tracing__"
+
+// Tracing a global middleware to trace each request in a server span continuing header `traceparent`.
+// The context holding the span is kept in the request, pass RequestContext to services so their spans
+// are children of the request span. It must be attached after RequestID middleware.
+//
+//	r.Use(middleware.RequestID(), middleware.Tracing())
+func Tracing() core.MiddlewareHandler {
+	return func(c *core.Ctx) error {
+		if !tracing.Enabled() {
+			return nil
+		}
+
+		ctx := tracing.Extract(context.Background(), string(c.Root().Request.Header.Peek(tracing.HeaderTraceParent)))
+		ctx, span := tracing.StartSpan(ctx, c.Method()+" "+RoutePattern(c), trace.SpanKindServer,
+			attribute.String("http.request.method", c.Method()),
+			attribute.String("http.route", RoutePattern(c)),
+			attribute.String("url.path", c.Path()),
+			attribute.String("client.address", ClientIP(c)),
+			attribute.String("request_id", CurrentRequestID(c)),
+		)
+
+		c.SetData(constant.TraceContext, ctx)
+
+		// fasthttp closes user values implementing io.Closer after the response was produced.
+		c.Root().SetUserValue(tracingKey, &requestSpan{
+			ctx:  c,
+			span: span,
+		})
+
+		return nil
+	}
+}
+
+// RequestContext get the context of current request holding its trace span, or an empty context
+// while tracing is disabled.
+//
+//	err := services.ForgotPassword(middleware.RequestContext(c), &forgotPassword)
+func RequestContext(c *core.Ctx) context.Context {
+	if ctx, ok := c.GetData(constant.TraceContext).(context.Context); ok {
+		return ctx
+	}
+
+	return context.Background()
+}
+
+// requestSpan a pending server span of a request.
+type requestSpan struct {
+	ctx  *core.Ctx
+	span trace.Span
+}
+
+// Close end the span. It implements io.Closer.
+func (s *requestSpan) Close() error {
+	status := s.ctx.Root().Response.StatusCode()
+	s.span.SetAttributes(attribute.Int("http.response.status_code", status))
+	if user := CurrentUser(s.ctx); user != nil {
+		s.span.SetAttributes(attribute.Int("enduser.id", user.ID))
+	}
+	if status >= core.StatusInternalServerError {
+		s.span.SetStatus(codes.Error, fasthttp.StatusMessage(status))
+	}
+	s.span.End()
+
+	return nil
+}
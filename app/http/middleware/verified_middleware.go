@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	"gfly/app/errors"
+	"gfly/app/services"
+	"github.com/gflydev/core"
+)
+
+// ====================================================================
+// ====================== Verification Middlewares ====================
+// ====================================================================
+
+// RequireVerified a middleware for API routes to refuse users who have not verified their email.
+// It must be attached after Auth middleware.
+//
+//	r.POST("/orders", middleware.Apply(order.NewCreateOrderApi(), middleware.RequireVerified()))
+func RequireVerified() core.MiddlewareHandler {
+	return func(c *core.Ctx) error {
+		user := CurrentUser(c)
+		if user == nil {
+			return errors.Render(c, errors.ErrUnauthorized)
+		}
+
+		if !user.VerifiedAt.Valid {
+			return errors.Render(c, services.ErrEmailNotVerified)
+		}
+
+		return nil
+	}
+}
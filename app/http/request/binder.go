@@ -0,0 +1,222 @@
+package request
+
+import (
+	"encoding/json"
+	"github.com/gflydev/core"
+	"github.com/gflydev/core/errors"
+	"mime/multipart"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// ====================================================================
+// ============================== Binder ==============================
+// ====================================================================
+
+// ErrInvalidBody request body can't be decoded into target struct.
+var ErrInvalidBody = errors.New("Invalid request body")
+
+// fileHeaderType types of multipart file fields.
+var (
+	fileHeaderType  = reflect.TypeOf((*multipart.FileHeader)(nil))
+	fileHeadersType = reflect.TypeOf([]*multipart.FileHeader{})
+)
+
+// Bind decode request data into given struct pointer then check its `validate` rules.
+// Body is decoded by content type: JSON, form or multipart. Fields tagged `query` are filled from
+// query string. Form fields are named by tag `form`, or tag `json` if missing.
+// Return ErrInvalidBody or ValidationErrors.
+//
+//	type CreateUser struct {
+//		Email  string   `json:"email" validate:"trim,lower,required,email,unique=users.email"`
+//		Status string   `json:"status" validate:"oneof=user_status"`
+//		Roles  []string `json:"roles" validate:"exists=roles.slug"`
+//	}
+//
+//	var createUser CreateUser
+//	if err := request.Bind(c, &createUser); err != nil {
+//		return request.Reject(c, err)
+//	}
+func Bind(c *core.Ctx, data any) error {
+	if err := Decode(c, data); err != nil {
+		return err
+	}
+
+	return Validate(data)
+}
+
+// Decode decode request data into given struct pointer without validation.
+func Decode(c *core.Ctx, data any) error {
+	target := reflect.ValueOf(data)
+	if target.Kind() != reflect.Ptr || target.Elem().Kind() != reflect.Struct {
+		return errors.New("Bind target must be a pointer to struct")
+	}
+
+	ctx := c.Root()
+	contentType := string(ctx.Request.Header.ContentType())
+	body := ctx.PostBody()
+
+	switch {
+	case strings.HasPrefix(contentType, "multipart/form-data"):
+		form, err := ctx.MultipartForm()
+		if err != nil {
+			return ErrInvalidBody
+		}
+		if err = decodeValues(target.Elem(), "form", func(name string) []string {
+			return form.Value[name]
+		}, func(name string) []*multipart.FileHeader {
+			return form.File[name]
+		}); err != nil {
+			return err
+		}
+	case strings.HasPrefix(contentType, "application/x-www-form-urlencoded"):
+		args := ctx.PostArgs()
+		if err := decodeValues(target.Elem(), "form", func(name string) []string {
+			return bytesToStrings(args.PeekMulti(name))
+		}, nil); err != nil {
+			return err
+		}
+	case len(body) > 0:
+		// JSON is the default body format of API
+		if err := json.Unmarshal(body, data); err != nil {
+			return ErrInvalidBody
+		}
+	}
+
+	args := ctx.QueryArgs()
+
+	return decodeValues(target.Elem(), "query", func(name string) []string {
+		return bytesToStrings(args.PeekMulti(name))
+	}, nil)
+}
+
+// decodeValues set struct fields from string values. Only fields having given tag are set,
+// except source `form` which falls back to tag `json`.
+func decodeValues(target reflect.Value, tag string, values func(string) []string, files func(string) []*multipart.FileHeader) error {
+	typ := target.Type()
+
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name := fieldName(field, tag)
+		if name == "" {
+			continue
+		}
+
+		value := target.Field(i)
+
+		// Multipart files
+		if field.Type == fileHeaderType || field.Type == fileHeadersType {
+			if files == nil {
+				continue
+			}
+			if headers := files(name); len(headers) > 0 {
+				if field.Type == fileHeaderType {
+					value.Set(reflect.ValueOf(headers[0]))
+				} else {
+					value.Set(reflect.ValueOf(headers))
+				}
+			}
+
+			continue
+		}
+
+		items := values(name)
+		if len(items) == 0 {
+			continue
+		}
+
+		if err := setValue(value, items); err != nil {
+			return ErrInvalidBody
+		}
+	}
+
+	return nil
+}
+
+// fieldName get name of a field for given source tag.
+func fieldName(field reflect.StructField, tag string) string {
+	name := strings.Split(field.Tag.Get(tag), ",")[0]
+	if name == "" && tag == "form" {
+		name = strings.Split(field.Tag.Get("json"), ",")[0]
+	}
+	if name == "-" {
+		return ""
+	}
+
+	return name
+}
+
+// setValue convert string values to type of given field.
+func setValue(value reflect.Value, items []string) error {
+	if value.Kind() == reflect.Ptr {
+		item := reflect.New(value.Type().Elem())
+		if err := setValue(item.Elem(), items); err != nil {
+			return err
+		}
+		value.Set(item)
+
+		return nil
+	}
+
+	if value.Kind() == reflect.Slice {
+		slice := reflect.MakeSlice(value.Type(), len(items), len(items))
+		for i, item := range items {
+			if err := setValue(slice.Index(i), []string{item}); err != nil {
+				return err
+			}
+		}
+		value.Set(slice)
+
+		return nil
+	}
+
+	item := items[0]
+
+	switch value.Kind() {
+	case reflect.String:
+		value.SetString(item)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(item)
+		if err != nil {
+			return err
+		}
+		value.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(item, 10, value.Type().Bits())
+		if err != nil {
+			return err
+		}
+		value.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(item, 10, value.Type().Bits())
+		if err != nil {
+			return err
+		}
+		value.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(item, value.Type().Bits())
+		if err != nil {
+			return err
+		}
+		value.SetFloat(n)
+	default:
+		return errors.New("Unsupported field type %s", value.Type())
+	}
+
+	return nil
+}
+
+// bytesToStrings convert multiple byte values to strings.
+func bytesToStrings(items [][]byte) []string {
+	values := make([]string, 0, len(items))
+	for _, item := range items {
+		values = append(values, string(item))
+	}
+
+	return values
+}
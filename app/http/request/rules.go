@@ -0,0 +1,212 @@
+package request
+
+import (
+	"gfly/app/domain/models"
+	"gfly/app/domain/repository"
+	"gfly/app/utils"
+	"github.com/gflydev/core/errors"
+	"github.com/gflydev/core/log"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// ====================================================================
+// ========================== Built-in Rules ==========================
+// ====================================================================
+
+// Auto-register built-in rules and value sets.
+func init() {
+	RegisterRule("trim", trimRule)
+	RegisterRule("lower", lowerRule)
+	RegisterRule("email", emailRule)
+	RegisterRule("phone", phoneRule)
+	RegisterRule("password", passwordRule)
+	RegisterRule("min", minRule)
+	RegisterRule("max", maxRule)
+	RegisterRule("oneof", oneofRule)
+	RegisterRule("unique", uniqueRule)
+	RegisterRule("exists", existsRule)
+
+	RegisterValues("user_status", models.UserState)
+	RegisterValues("address_type", models.AddressTypes)
+	RegisterValues("role", models.RoleNA.Values()[1:])
+}
+
+// trimRule remove leading and trailing spaces of a string.
+func trimRule(value reflect.Value, _ string) error {
+	if value.Kind() == reflect.String && value.CanSet() {
+		value.SetString(strings.TrimSpace(value.String()))
+	}
+
+	return nil
+}
+
+// lowerRule convert a string to lower case.
+func lowerRule(value reflect.Value, _ string) error {
+	if value.Kind() == reflect.String && value.CanSet() {
+		value.SetString(strings.ToLower(value.String()))
+	}
+
+	return nil
+}
+
+// emailRule check a string or each string of a slice is an email address.
+func emailRule(value reflect.Value, _ string) error {
+	return each(value, func(item reflect.Value) error {
+		if !utils.IsEmail(item.String()) {
+			return errors.New("must be a valid email address")
+		}
+
+		return nil
+	})
+}
+
+// phoneRule check a string is a phone number.
+func phoneRule(value reflect.Value, _ string) error {
+	if !utils.IsPhone(value.String()) {
+		return errors.New("must be a valid phone number")
+	}
+
+	return nil
+}
+
+// passwordRule check a string follows password policy.
+func passwordRule(value reflect.Value, _ string) error {
+	if err := utils.CheckPassword(value.String()); err != nil {
+		return errors.New("must have at least %d characters with upper case, lower case letters and digits", utils.MinPasswordLength)
+	}
+
+	return nil
+}
+
+// minRule check minimum length of a string or slice, or minimum of a number. Ex: `min=8`.
+func minRule(value reflect.Value, param string) error {
+	limit, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		log.Panicf("Invalid parameter of rule `min=%s`", param)
+	}
+
+	size, unit := measure(value)
+	if size < limit {
+		return errors.New("must be at least %s%s", param, unit)
+	}
+
+	return nil
+}
+
+// maxRule check maximum length of a string or slice, or maximum of a number. Ex: `max=255`.
+func maxRule(value reflect.Value, param string) error {
+	limit, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		log.Panicf("Invalid parameter of rule `max=%s`", param)
+	}
+
+	size, unit := measure(value)
+	if size > limit {
+		return errors.New("must not be greater than %s%s", param, unit)
+	}
+
+	return nil
+}
+
+// oneofRule check a string or each string of a slice is in a registered value set or in values
+// separated by `|`. Ex: `oneof=user_status`, `oneof=asc|desc`.
+func oneofRule(value reflect.Value, param string) error {
+	values := valuesOf(param)
+
+	return each(value, func(item reflect.Value) error {
+		if !slices.Contains(values, item.String()) {
+			return errors.New("must be one of: %s", strings.Join(values, ", "))
+		}
+
+		return nil
+	})
+}
+
+// uniqueRule check no row of a table has the value. Ex: `unique=users.email`.
+func uniqueRule(value reflect.Value, param string) error {
+	table, column := tableColumn("unique", param)
+
+	total, err := repository.CountBy(table, column, value.Interface())
+	if err != nil {
+		log.Error(err)
+
+		return errors.New("can not be verified")
+	}
+
+	if total > 0 {
+		return errors.New("has already been taken")
+	}
+
+	return nil
+}
+
+// existsRule check a row of a table has the value, or each value of a slice. Ex: `exists=roles.slug`.
+func existsRule(value reflect.Value, param string) error {
+	table, column := tableColumn("exists", param)
+
+	return each(value, func(item reflect.Value) error {
+		total, err := repository.CountBy(table, column, item.Interface())
+		if err != nil {
+			log.Error(err)
+
+			return errors.New("can not be verified")
+		}
+
+		if total == 0 {
+			return errors.New("does not exist")
+		}
+
+		return nil
+	})
+}
+
+// ====================================================================
+// ============================== Helpers =============================
+// ====================================================================
+
+// each apply a check on a value, or on each item if the value is a slice.
+func each(value reflect.Value, check func(item reflect.Value) error) error {
+	if value.Kind() != reflect.Slice {
+		return check(value)
+	}
+
+	for i := 0; i < value.Len(); i++ {
+		if err := check(value.Index(i)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// measure get size of a value to compare with min/max and its unit.
+func measure(value reflect.Value) (float64, string) {
+	switch value.Kind() {
+	case reflect.String:
+		return float64(utf8.RuneCountInString(value.String())), " characters"
+	case reflect.Slice, reflect.Map, reflect.Array:
+		return float64(value.Len()), " items"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(value.Int()), ""
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(value.Uint()), ""
+	case reflect.Float32, reflect.Float64:
+		return value.Float(), ""
+	}
+
+	return 0, ""
+}
+
+// tableColumn parse parameter `table.column` of a database rule.
+func tableColumn(rule, param string) (string, string) {
+	table, column, ok := strings.Cut(param, ".")
+	if !ok || table == "" || column == "" {
+		log.Panicf("Invalid parameter of rule `%s=%s`, expected `table.column`", rule, param)
+	}
+
+	return table, column
+}
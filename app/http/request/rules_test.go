@@ -0,0 +1,89 @@
+package request
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRules(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    Rule
+		value   any
+		param   string
+		wantErr string
+	}{
+		{"email valid", emailRule, "john@gfly.dev", "", ""},
+		{"email invalid", emailRule, "john@", "", "must be a valid email address"},
+		{"email with name", emailRule, "John <john@gfly.dev>", "", "must be a valid email address"},
+		{"email each item", emailRule, []string{"a@gfly.dev", "b"}, "", "must be a valid email address"},
+		{"phone valid", phoneRule, "+84 989 831 911", "", ""},
+		{"phone invalid", phoneRule, "12ab", "", "must be a valid phone number"},
+		{"password valid", passwordRule, "Secret123", "", ""},
+		{"password weak", passwordRule, "secret123", "", "must have at least 8 characters with upper case, lower case letters and digits"},
+		{"min string", minRule, "abc", "4", "must be at least 4 characters"},
+		{"min string runes", minRule, "ếếế", "3", ""},
+		{"min slice", minRule, []string{"a"}, "2", "must be at least 2 items"},
+		{"min number", minRule, 5, "5", ""},
+		{"max string", maxRule, "abcdef", "5", "must not be greater than 5 characters"},
+		{"max number", maxRule, 11.5, "10", "must not be greater than 10"},
+		{"max uint", maxRule, uint(3), "10", ""},
+		{"oneof inline", oneofRule, "asc", "asc|desc", ""},
+		{"oneof inline invalid", oneofRule, "up", "asc|desc", "must be one of: asc, desc"},
+		{"oneof value set", oneofRule, "blocked", "user_status", ""},
+		{"oneof value set invalid", oneofRule, "gone", "user_status", "must be one of: "},
+		{"oneof each item", oneofRule, []string{"admin", "root"}, "role", "must be one of: "},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.rule(reflect.ValueOf(tt.value), tt.param)
+
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Fatalf("unexpected error: %v", err)
+			case tt.wantErr != "" && err == nil:
+				t.Fatalf("expected error %q, got nil", tt.wantErr)
+			case tt.wantErr != "" && !hasPrefix(err.Error(), tt.wantErr):
+				t.Fatalf("expected error %q, got %q", tt.wantErr, err.Error())
+			}
+		})
+	}
+}
+
+func TestMutatorRules(t *testing.T) {
+	tests := []struct {
+		name string
+		rule Rule
+		in   string
+		want string
+	}{
+		{"trim", trimRule, "  john@gfly.dev \t", "john@gfly.dev"},
+		{"lower", lowerRule, "John@GFLY.dev", "john@gfly.dev"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value := tt.in
+			if err := tt.rule(reflect.ValueOf(&value).Elem(), ""); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if value != tt.want {
+				t.Fatalf("expected %q, got %q", tt.want, value)
+			}
+		})
+	}
+}
+
+func TestTableColumn(t *testing.T) {
+	table, column := tableColumn("unique", "users.email")
+	if table != "users" || column != "email" {
+		t.Fatalf("expected users.email, got %s.%s", table, column)
+	}
+}
+
+// hasPrefix check message starts with given prefix. Value sets are listed in full after the prefix.
+func hasPrefix(message, prefix string) bool {
+	return len(message) >= len(prefix) && message[:len(prefix)] == prefix
+}
@@ -0,0 +1,210 @@
+package request
+
+import (
+	"fmt"
+	"gfly/app/errors"
+	"github.com/gflydev/core"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ====================================================================
+// ============================ Data Types ============================
+// ====================================================================
+
+// Rule a validation rule. It gets field value and the rule parameter, the text after `=` in tag
+// `validate:"max=255"`. Return an error with a short message like "must be a valid email address"
+// when the value is invalid.
+type Rule func(value reflect.Value, param string) error
+
+// ValidationErrors error messages of invalid fields, keyed by field name.
+type ValidationErrors map[string][]string
+
+// Error implement interface error.
+func (e ValidationErrors) Error() string {
+	fields := make([]string, 0, len(e))
+	for field := range e {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	messages := make([]string, 0, len(fields))
+	for _, field := range fields {
+		messages = append(messages, field+" "+strings.Join(e[field], ", "))
+	}
+
+	return strings.Join(messages, "; ")
+}
+
+// add append a message for given field.
+func (e ValidationErrors) add(field, message string) {
+	e[field] = append(e[field], message)
+}
+
+// ====================================================================
+// ========================= Rule Registration ========================
+// ====================================================================
+
+var (
+	registryMutex sync.RWMutex
+	rules         = map[string]Rule{}
+	valueSets     = map[string][]string{}
+)
+
+// RegisterRule add a named rule. Register in `init()` of the package owning the rule.
+//
+//	func init() {
+//		request.RegisterRule("slug", func(value reflect.Value, param string) error {
+//			if !slugPattern.MatchString(value.String()) {
+//				return errors.New("must be a slug")
+//			}
+//			return nil
+//		})
+//	}
+func RegisterRule(name string, rule Rule) {
+	registryMutex.Lock()
+	defer registryMutex.Unlock()
+
+	rules[name] = rule
+}
+
+// RegisterValues add a named set of values for rule `oneof`. Ex: `validate:"oneof=user_status"`.
+func RegisterValues(name string, values []string) {
+	registryMutex.Lock()
+	defer registryMutex.Unlock()
+
+	valueSets[name] = values
+}
+
+// ruleOf get a registered rule.
+func ruleOf(name string) (Rule, bool) {
+	registryMutex.RLock()
+	defer registryMutex.RUnlock()
+
+	rule, ok := rules[name]
+
+	return rule, ok
+}
+
+// valuesOf get a registered value set, or values separated by `|`. Ex: `oneof=asc|desc`.
+func valuesOf(param string) []string {
+	registryMutex.RLock()
+	defer registryMutex.RUnlock()
+
+	if values, ok := valueSets[param]; ok {
+		return values
+	}
+
+	return strings.Split(param, "|")
+}
+
+// ====================================================================
+// ============================ Validation ============================
+// ====================================================================
+
+// requiredRule name of rule for mandatory fields. Other rules skip empty values.
+const requiredRule = "required"
+
+// mutatorRules rules which normalize value instead of checking it. They also run on empty values.
+var mutatorRules = []string{"trim", "lower"}
+
+// Validate check rules in tag `validate` of all fields of given struct (or pointer to struct).
+// Rules run in order and stop at the first failure of each field. Return ValidationErrors if any.
+func Validate(data any) error {
+	target := reflect.ValueOf(data)
+	for target.Kind() == reflect.Ptr {
+		target = target.Elem()
+	}
+
+	typ := target.Type()
+	errs := ValidationErrors{}
+
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+
+		tag := field.Tag.Get("validate")
+		if tag == "" || !field.IsExported() {
+			continue
+		}
+
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name == "" || name == "-" {
+			name = field.Name
+		}
+
+		value := target.Field(i)
+		if value.Kind() == reflect.Ptr && !value.IsNil() {
+			value = value.Elem()
+		}
+
+		for _, item := range strings.Split(tag, ",") {
+			ruleName, param, _ := strings.Cut(strings.TrimSpace(item), "=")
+
+			if ruleName == requiredRule {
+				if isEmpty(value) {
+					errs.add(name, "is required")
+
+					break
+				}
+
+				continue
+			}
+
+			if isEmpty(value) && !isMutator(ruleName) {
+				continue
+			}
+
+			rule, ok := ruleOf(ruleName)
+			if !ok {
+				panic(fmt.Sprintf("Unknown validation rule `%s` of field %s", ruleName, field.Name))
+			}
+
+			if err := rule(value, param); err != nil {
+				errs.add(name, err.Error())
+
+				break
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// Reject render a binding error. Validation errors get 422 with messages per field,
+// other errors get 400.
+func Reject(c *core.Ctx, err error) error {
+	if errs, ok := err.(ValidationErrors); ok {
+		return errors.Render(c, errors.Validation(errors.ErrValidation.Message, errs))
+	}
+
+	return errors.Render(c, errors.BadRequest(err.Error()))
+}
+
+// isEmpty check value is zero, nil or an empty collection.
+func isEmpty(value reflect.Value) bool {
+	switch value.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		return value.IsNil()
+	case reflect.Slice, reflect.Map, reflect.Array, reflect.String:
+		return value.Len() == 0
+	}
+
+	return value.IsZero()
+}
+
+// isMutator check rule normalizes value.
+func isMutator(name string) bool {
+	for _, mutator := range mutatorRules {
+		if mutator == name {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,141 @@
+package request
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// signUpForm a form covering required, mutator, chained and pointer fields.
+type signUpForm struct {
+	Email    string   `json:"email" validate:"required,trim,lower,email,max=255"`
+	Password string   `json:"password" validate:"required,password"`
+	Fullname string   `json:"fullname" validate:"trim,max=10"`
+	Phone    *string  `json:"phone" validate:"phone"`
+	Roles    []string `json:"roles" validate:"min=1,oneof=role"`
+	Secret   string   `json:"-" validate:"required"`
+	note     string   `validate:"required"`
+}
+
+func TestValidate(t *testing.T) {
+	phone := "0989831911"
+	badPhone := "12"
+
+	tests := []struct {
+		name string
+		form signUpForm
+		want ValidationErrors
+	}{
+		{
+			name: "valid",
+			form: signUpForm{Email: "john@gfly.dev", Password: "Secret123", Phone: &phone, Roles: []string{"admin"}, Secret: "x"},
+		},
+		{
+			name: "required fields",
+			form: signUpForm{},
+			want: ValidationErrors{
+				"email":    {"is required"},
+				"password": {"is required"},
+				"Secret":   {"is required"},
+			},
+		},
+		{
+			name: "stop at first failure of a field",
+			form: signUpForm{Email: strings.Repeat("x", 300), Password: "Secret123", Secret: "x"},
+			want: ValidationErrors{"email": {"must be a valid email address"}},
+		},
+		{
+			name: "empty optional fields are skipped",
+			form: signUpForm{Email: "john@gfly.dev", Password: "Secret123", Fullname: "   ", Secret: "x"},
+		},
+		{
+			name: "nil pointer is skipped, set pointer is checked",
+			form: signUpForm{Email: "john@gfly.dev", Password: "Secret123", Phone: &badPhone, Secret: "x"},
+			want: ValidationErrors{"phone": {"must be a valid phone number"}},
+		},
+		{
+			name: "slice rules",
+			form: signUpForm{Email: "john@gfly.dev", Password: "Secret123", Roles: []string{"root"}, Secret: "x"},
+			want: ValidationErrors{"roles": {"must be one of: admin, moderator, member, user, guest"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(&tt.form)
+
+			if tt.want == nil {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+
+				return
+			}
+
+			errs, ok := err.(ValidationErrors)
+			if !ok {
+				t.Fatalf("expected ValidationErrors, got %v", err)
+			}
+
+			if !reflect.DeepEqual(errs, tt.want) {
+				t.Fatalf("expected %v, got %v", tt.want, errs)
+			}
+		})
+	}
+}
+
+func TestValidateMutators(t *testing.T) {
+	form := signUpForm{Email: "  John@GFLY.dev ", Password: "Secret123", Fullname: " John ", Secret: "x"}
+
+	if err := Validate(&form); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if form.Email != "john@gfly.dev" || form.Fullname != "John" {
+		t.Fatalf("expected normalized fields, got %q and %q", form.Email, form.Fullname)
+	}
+}
+
+func TestValidateUnknownRule(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected panic on unknown rule")
+		}
+	}()
+
+	_ = Validate(struct {
+		Name string `validate:"slug"`
+	}{Name: "gfly"})
+}
+
+func TestValidationErrorsError(t *testing.T) {
+	errs := ValidationErrors{
+		"password": {"is required"},
+		"email":    {"is required", "must be a valid email address"},
+	}
+
+	want := "email is required, must be a valid email address; password is required"
+	if errs.Error() != want {
+		t.Fatalf("expected %q, got %q", want, errs.Error())
+	}
+}
+
+func TestRegisterRule(t *testing.T) {
+	RegisterRule("even", func(value reflect.Value, _ string) error {
+		if value.Int()%2 != 0 {
+			return errors.New("must be even")
+		}
+
+		return nil
+	})
+	RegisterValues("sizes", []string{"s", "m"})
+
+	if _, ok := ruleOf("even"); !ok {
+		t.Fatal("expected rule `even` to be registered")
+	}
+
+	if values := valuesOf("sizes"); !reflect.DeepEqual(values, []string{"s", "m"}) {
+		t.Fatalf("expected registered value set, got %v", values)
+	}
+}
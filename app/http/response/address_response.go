@@ -0,0 +1,25 @@
+package response
+
+import "time"
+
+// Address struct to describe an address response.
+type Address struct {
+	ID           int        `json:"id" example:"1"`
+	UserID       int        `json:"user_id" example:"1"`
+	Type         string     `json:"type" example:"shipping"`
+	IsDefault    bool       `json:"is_default" example:"true"`
+	AddressLine1 string     `json:"address_line1" example:"12 Nguyen Hue"`
+	AddressLine2 string     `json:"address_line2" example:"Floor 3"`
+	Ward         string     `json:"ward" example:"Ben Nghe"`
+	District     string     `json:"district" example:"District 1"`
+	City         string     `json:"city" example:"Ho Chi Minh"`
+	State        string     `json:"state" example:""`
+	Country      string     `json:"country" example:"Vietnam"`
+	CreatedAt    time.Time  `json:"created_at" example:"2024-05-15T13:07:48Z"`
+	UpdatedAt    *time.Time `json:"updated_at" example:"2024-05-15T13:07:48Z"`
+}
+
+// ListAddress struct to describe a list of addresses.
+type ListAddress struct {
+	Data []Address `json:"data"`
+}
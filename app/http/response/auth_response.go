@@ -0,0 +1,13 @@
+package response
+
+// Token struct to describe a token pair response.
+type Token struct {
+	Access    string `json:"access" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
+	Refresh   string `json:"refresh" example:"d1a5b6c0e0f14f5a8b1f6d3c2a9e7b40"`
+	ExpiresAt int64  `json:"expires_at" example:"1715760468"`
+}
+
+// Message struct to describe a plain message response.
+type Message struct {
+	Message string `json:"message" example:"If the email exists, a reset link has been sent"`
+}
@@ -0,0 +1,11 @@
+package response
+
+import "github.com/gflydev/core"
+
+// Error struct to describe an API error response.
+type Error struct {
+	Code      string    `json:"code" example:"BAD_REQUEST"`
+	Message   string    `json:"message" example:"Bad request"`
+	Data      core.Data `json:"data,omitempty" swaggertype:"object"`
+	RequestID string    `json:"request_id,omitempty" example:"3f2a9c1d7e6b4a5c8d9e0f1a2b3c4d5e"`
+}
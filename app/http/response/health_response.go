@@ -0,0 +1,16 @@
+package response
+
+// Health struct to describe liveness and readiness response.
+type Health struct {
+	Status string        `json:"status" example:"up"`
+	Checks []HealthCheck `json:"checks,omitempty"`
+}
+
+// HealthCheck struct to describe result of a dependency check.
+type HealthCheck struct {
+	Name      string  `json:"name" example:"database"`
+	Status    string  `json:"status" example:"up"`
+	Critical  bool    `json:"critical" example:"true"`
+	LatencyMs float64 `json:"latency_ms" example:"1.25"`
+	Error     string  `json:"error,omitempty" example:"timeout"`
+}
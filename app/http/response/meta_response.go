@@ -0,0 +1,24 @@
+package response
+
+// Meta struct to describe pagination metadata of a list response.
+type Meta struct {
+	Page     int `json:"page" example:"1"`
+	PerPage  int `json:"per_page" example:"20"`
+	Total    int `json:"total" example:"120"`
+	LastPage int `json:"last_page" example:"6"`
+}
+
+// NewMeta create pagination metadata.
+func NewMeta(page, perPage, total int) Meta {
+	lastPage := 1
+	if perPage > 0 && total > 0 {
+		lastPage = (total + perPage - 1) / perPage
+	}
+
+	return Meta{
+		Page:     page,
+		PerPage:  perPage,
+		Total:    total,
+		LastPage: lastPage,
+	}
+}
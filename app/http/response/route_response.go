@@ -0,0 +1,9 @@
+package response
+
+// Route struct to describe a mounted route.
+type Route struct {
+	Method      string   `json:"method" example:"GET"`
+	Path        string   `json:"path" example:"/api/v1/users/{id}"`
+	Handler     string   `json:"handler" example:"user.GetUserApi"`
+	Middlewares []string `json:"middlewares" example:"middleware.RequestID,middleware.Auth"`
+}
@@ -0,0 +1,26 @@
+package response
+
+import "time"
+
+// User struct to describe a user response. Sensitive fields `password` and `token` are never exposed.
+type User struct {
+	ID           int        `json:"id" example:"1"`
+	Email        string     `json:"email" example:"admin@gfly.dev"`
+	Fullname     string     `json:"fullname" example:"Admin"`
+	Phone        string     `json:"phone" example:"0989831911"`
+	Avatar       string     `json:"avatar" example:"https://www.gfly.dev/assets/avatar.png"`
+	Status       string     `json:"status" example:"active"`
+	Roles        []string   `json:"roles" example:"admin"`
+	CreatedAt    time.Time  `json:"created_at" example:"2024-05-15T13:07:48Z"`
+	UpdatedAt    time.Time  `json:"updated_at" example:"2024-05-15T13:07:48Z"`
+	VerifiedAt   *time.Time `json:"verified_at" example:"2024-05-15T13:07:48Z"`
+	BlockedAt    *time.Time `json:"blocked_at" example:"2024-05-15T13:07:48Z"`
+	LastAccessAt *time.Time `json:"last_access_at" example:"2024-05-15T13:07:48Z"`
+	DeletedAt    *time.Time `json:"deleted_at,omitempty" example:"2024-05-15T13:07:48Z"`
+}
+
+// ListUser struct to describe a paginated list of users.
+type ListUser struct {
+	Meta Meta   `json:"meta"`
+	Data []User `json:"data"`
+}
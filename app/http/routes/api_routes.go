@@ -2,22 +2,95 @@ package routes
 
 import (
 	"fmt"
+	"gfly/app/domain/models"
 	"gfly/app/http/controllers/api"
+	"gfly/app/http/controllers/api/address"
+	"gfly/app/http/controllers/api/auth"
+	"gfly/app/http/controllers/api/user"
+	"gfly/app/http/middleware"
 	"github.com/gflydev/core"
 	"github.com/gflydev/core/utils"
 )
 
 // ApiRoutes func for describe a group of API routes.
-func ApiRoutes(r core.IFlyRouter) {
-	prefixAPI := fmt.Sprintf(
+func ApiRoutes(r *Group) {
+	prefixAPI := apiPrefix()
+
+	// API Routers
+	r.Group(prefixAPI, func(r *Group) {
+		// Require bearer token for all API routes except public ones
+		r.Use(middleware.Auth(
+			prefixAPI+"/info",
+			prefixAPI+"/auth/signup",
+			prefixAPI+"/auth/signin",
+			prefixAPI+"/auth/refresh",
+			prefixAPI+"/auth/forgot-password",
+			prefixAPI+"/auth/reset-password",
+			prefixAPI+"/auth/verify-email",
+			prefixAPI+"/auth/verify-email/resend",
+		))
+
+		// Throttle all API requests per user, or per IP for guests
+		r.Use(middleware.RateLimit("api", middleware.PerMinute(utils.Getenv("RATE_LIMIT_API", 600)), middleware.ByUser))
+
+		// curl -v -X GET http://localhost:7789/api/v1/info | jq
+		r.GET("/info", middleware.Apply(api.NewDefaultApi()))
+
+		// Auth Routers
+		r.Group("/auth", func(r *Group) {
+			// curl -v -X POST http://localhost:7789/api/v1/auth/signup -d '{"email":"john@gfly.dev","password":"P@seWor9","fullname":"John Doe","phone":"0989831911"}' | jq
+			r.POST("/signup", middleware.Apply(auth.NewSignUpApi(), authRateLimit("signup")))
+			// curl -v -X POST http://localhost:7789/api/v1/auth/signin -d '{"email":"admin@gfly.dev","password":"P@seWor9"}' | jq
+			r.POST("/signin", middleware.Apply(auth.NewSignInApi(), authRateLimit("signin")))
+			// curl -v -X POST http://localhost:7789/api/v1/auth/refresh -d '{"token":"<refresh token>"}' | jq
+			r.POST("/refresh", middleware.Apply(auth.NewRefreshTokenApi()))
+			// curl -v -X POST http://localhost:7789/api/v1/auth/signout -H 'Authorization: Bearer <access token>' -d '{"token":"<refresh token>"}'
+			r.POST("/signout", middleware.Apply(auth.NewSignOutApi()))
+			// curl -v -X POST http://localhost:7789/api/v1/auth/forgot-password -d '{"email":"admin@gfly.dev"}' | jq
+			r.POST("/forgot-password", middleware.Apply(auth.NewForgotPasswordApi(), authRateLimit("forgot-password")))
+			// curl -v -X POST http://localhost:7789/api/v1/auth/reset-password -d '{"token":"<token>","expires":<expires>,"signature":"<signature>","password":"N3wP@seWor9"}' | jq
+			r.POST("/reset-password", middleware.Apply(auth.NewResetPasswordApi(), authRateLimit("reset-password")))
+			// Signed link from verification email
+			r.GET("/verify-email", middleware.Apply(auth.NewVerifyEmailApi()))
+			// curl -v -X POST http://localhost:7789/api/v1/auth/verify-email/resend -d '{"email":"john@gfly.dev"}' | jq
+			r.POST("/verify-email/resend", middleware.Apply(auth.NewResendVerificationApi(), authRateLimit("verify-email-resend")))
+		})
+
+		// User Routers
+		r.Group("/users", func(r *Group) {
+			// curl -v -X GET 'http://localhost:7789/api/v1/users?status=active&keyword=admin&sort=created_at&order=desc&page=1&per_page=20' -H 'Authorization: Bearer <access token>' | jq
+			r.GET("", middleware.Apply(user.NewListUsersApi(), middleware.RequirePermissions(models.PermissionUsersList)))
+			r.POST("", middleware.Apply(user.NewCreateUserApi(), middleware.RequirePermissions(models.PermissionUsersCreate)))
+			r.GET("/{id}", middleware.Apply(user.NewGetUserApi(), middleware.RequirePermissions(models.PermissionUsersView)))
+			r.PUT("/{id}", middleware.Apply(user.NewUpdateUserApi(), middleware.RequirePermissions(models.PermissionUsersUpdate)))
+			r.DELETE("/{id}", middleware.Apply(user.NewDeleteUserApi(), middleware.RequirePermissions(models.PermissionUsersDelete)))
+			// curl -v -X POST http://localhost:7789/api/v1/users/2/restore -H 'Authorization: Bearer <access token>' | jq
+			r.POST("/{id}/restore", middleware.Apply(user.NewRestoreUserApi(), middleware.RequirePermissions(models.PermissionUsersDelete)))
+
+			// Address Routers. Owners manage their own addresses, others need `users.*` permissions.
+			r.Group("/{id}/addresses", func(r *Group) {
+				// curl -v -X GET http://localhost:7789/api/v1/users/1/addresses -H 'Authorization: Bearer <access token>' | jq
+				r.GET("", middleware.Apply(address.NewListAddressesApi()))
+				// curl -v -X POST http://localhost:7789/api/v1/users/1/addresses -H 'Authorization: Bearer <access token>' -d '{"type":"shipping","is_default":true,"address_line1":"12 Nguyen Hue","city":"Ho Chi Minh","country":"Vietnam"}' | jq
+				r.POST("", middleware.Apply(address.NewCreateAddressApi()))
+				r.GET("/{address_id}", middleware.Apply(address.NewGetAddressApi()))
+				r.PUT("/{address_id}", middleware.Apply(address.NewUpdateAddressApi()))
+				r.DELETE("/{address_id}", middleware.Apply(address.NewDeleteAddressApi()))
+			})
+		})
+	})
+}
+
+// apiPrefix get path prefix of API routes. Ex: /api/v1
+func apiPrefix() string {
+	return fmt.Sprintf(
 		"/%s/%s",
 		utils.Getenv("API_PREFIX", "api"),
 		utils.Getenv("API_VERSION", "v1"),
 	)
+}
 
-	// API Routers
-	r.Group(prefixAPI, func(r *core.Group) {
-		// curl -v -X GET http://localhost:7789/api/v1/info | jq
-		r.GET("/info", api.NewDefaultApi())
-	})
+// authRateLimit throttle public authentication routes per client IP to slow down brute force attacks.
+func authRateLimit(name string) core.MiddlewareHandler {
+	return middleware.RateLimit("auth:"+name, middleware.PerMinute(utils.Getenv("RATE_LIMIT_AUTH", 5)))
 }
@@ -0,0 +1,191 @@
+package routes
+
+import (
+	"fmt"
+	"gfly/app/http/middleware"
+	"gfly/app/http/response"
+	"github.com/gflydev/core"
+	"net/http"
+	"reflect"
+	"regexp"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// ====================================================================
+// =========================== Route Group ============================
+// ====================================================================
+
+// coreRouter routers of gFly core: the application and its groups.
+type coreRouter interface {
+	core.IFlyRouter
+	Use(middlewares ...core.MiddlewareHandler)
+}
+
+// Group a router which registers routes into gFly core and records them with their full path,
+// handler type and middlewares. A group without core router only records routes.
+type Group struct {
+	router      coreRouter
+	prefix      string
+	middlewares []string
+	table       *[]response.Route
+}
+
+// newGroup create a root group of router.
+func newGroup(router coreRouter) *Group {
+	return &Group{router: router, table: &[]response.Route{}}
+}
+
+// Use add middlewares to the group. Their panics are recovered.
+func (g *Group) Use(middlewares ...core.MiddlewareHandler) {
+	recovered := make([]core.MiddlewareHandler, 0, len(middlewares))
+	for _, m := range middlewares {
+		g.middlewares = append(g.middlewares, funcName(m))
+		recovered = append(recovered, middleware.Recover(m))
+	}
+
+	if g.router != nil {
+		g.router.Use(recovered...)
+	}
+}
+
+// Group add a sub group of path.
+func (g *Group) Group(path string, groupFunc func(*Group)) {
+	sub := func(router coreRouter) *Group {
+		return &Group{
+			router:      router,
+			prefix:      g.prefix + path,
+			middlewares: append([]string{}, g.middlewares...),
+			table:       g.table,
+		}
+	}
+
+	if g.router == nil {
+		groupFunc(sub(nil))
+
+		return
+	}
+
+	g.router.Group(path, func(r *core.Group) {
+		groupFunc(sub(r))
+	})
+}
+
+// GET add a route of method GET.
+func (g *Group) GET(path string, handler core.IHandler) {
+	g.add(http.MethodGet, path, handler, coreRouter.GET)
+}
+
+// POST add a route of method POST.
+func (g *Group) POST(path string, handler core.IHandler) {
+	g.add(http.MethodPost, path, handler, coreRouter.POST)
+}
+
+// PUT add a route of method PUT.
+func (g *Group) PUT(path string, handler core.IHandler) {
+	g.add(http.MethodPut, path, handler, coreRouter.PUT)
+}
+
+// PATCH add a route of method PATCH.
+func (g *Group) PATCH(path string, handler core.IHandler) {
+	g.add(http.MethodPatch, path, handler, coreRouter.PATCH)
+}
+
+// DELETE add a route of method DELETE.
+func (g *Group) DELETE(path string, handler core.IHandler) {
+	g.add(http.MethodDelete, path, handler, coreRouter.DELETE)
+}
+
+// HEAD add a route of method HEAD.
+func (g *Group) HEAD(path string, handler core.IHandler) {
+	g.add(http.MethodHead, path, handler, coreRouter.HEAD)
+}
+
+// OPTIONS add a route of method OPTIONS.
+func (g *Group) OPTIONS(path string, handler core.IHandler) {
+	g.add(http.MethodOptions, path, handler, coreRouter.OPTIONS)
+}
+
+// add record a route then register it into core router. Panics of the handler and its route middlewares
+// are recovered, with or without Apply.
+func (g *Group) add(method, path string, handler core.IHandler, register func(coreRouter, string, core.IHandler)) {
+	inner, routeMiddlewares := middleware.Unwrap(handler)
+
+	middlewares := append([]string{}, g.middlewares...)
+	for _, m := range routeMiddlewares {
+		middlewares = append(middlewares, funcName(m))
+	}
+
+	fullPath := g.prefix + path
+	if fullPath == "" {
+		fullPath = "/"
+	}
+
+	*g.table = append(*g.table, response.Route{
+		Method:      method,
+		Path:        fullPath,
+		Handler:     strings.TrimPrefix(fmt.Sprintf("%T", inner), "*"),
+		Middlewares: middlewares,
+	})
+
+	if g.router != nil {
+		register(g.router, path, middleware.Apply(inner, routeMiddlewares...))
+	}
+}
+
+// closureSuffix suffix of anonymous functions returned by middleware constructors. Ex: .func1, .func2.1, .1
+var closureSuffix = regexp.MustCompile(`(\.(func)?\d+)+$`)
+
+// funcName get short name of a middleware function. Ex: middleware.RequirePermissions
+func funcName(fn core.MiddlewareHandler) string {
+	f := runtime.FuncForPC(reflect.ValueOf(fn).Pointer())
+	if f == nil {
+		return "?"
+	}
+
+	name := closureSuffix.ReplaceAllString(f.Name(), "")
+
+	return name[strings.LastIndex(name, "/")+1:]
+}
+
+// ====================================================================
+// =========================== Route Table ============================
+// ====================================================================
+
+var (
+	mountedLock sync.RWMutex
+	mounted     []response.Route
+)
+
+// Mounted get routes mounted into the web server by Router.
+func Mounted() []response.Route {
+	mountedLock.RLock()
+	defer mountedLock.RUnlock()
+
+	return mounted
+}
+
+// List get routes of the application without starting a web server.
+func List() []response.Route {
+	group := newGroup(nil)
+	register(group)
+
+	return *group.table
+}
+
+// Filter get routes of method and under path prefix. Empty method or prefix matches all routes.
+func Filter(routes []response.Route, method, prefix string) []response.Route {
+	filtered := make([]response.Route, 0, len(routes))
+	for _, route := range routes {
+		if method != "" && !strings.EqualFold(route.Method, method) {
+			continue
+		}
+		if prefix != "" && !strings.HasPrefix(route.Path, prefix) {
+			continue
+		}
+		filtered = append(filtered, route)
+	}
+
+	return filtered
+}
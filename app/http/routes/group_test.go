@@ -0,0 +1,62 @@
+package routes
+
+import (
+	"gfly/app/http/middleware"
+	"github.com/gflydev/core"
+	"testing"
+)
+
+// fakeRouter a core router recording registered middlewares and handlers.
+type fakeRouter struct {
+	middlewares []core.MiddlewareHandler
+	handlers    map[string]core.IHandler
+}
+
+func (r *fakeRouter) GET(path string, handler core.IHandler)    { r.handlers["GET "+path] = handler }
+func (r *fakeRouter) POST(path string, handler core.IHandler)   { r.handlers["POST "+path] = handler }
+func (r *fakeRouter) PUT(path string, handler core.IHandler)    { r.handlers["PUT "+path] = handler }
+func (r *fakeRouter) PATCH(path string, handler core.IHandler)  { r.handlers["PATCH "+path] = handler }
+func (r *fakeRouter) DELETE(path string, handler core.IHandler) { r.handlers["DELETE "+path] = handler }
+func (r *fakeRouter) HEAD(path string, handler core.IHandler)   { r.handlers["HEAD "+path] = handler }
+func (r *fakeRouter) OPTIONS(path string, handler core.IHandler) {
+	r.handlers["OPTIONS "+path] = handler
+}
+func (r *fakeRouter) Group(_ string, _ func(*core.Group)) {}
+
+// Use implement coreRouter.
+func (r *fakeRouter) Use(middlewares ...core.MiddlewareHandler) {
+	r.middlewares = append(r.middlewares, middlewares...)
+}
+
+// panicking a group middleware which panics.
+func panicking(_ *core.Ctx) error {
+	panic("boom")
+}
+
+// plainHandler a handler registered without Apply.
+type plainHandler struct {
+	core.Api
+}
+
+func TestGroupRecoversMiddlewaresAndHandlers(t *testing.T) {
+	router := &fakeRouter{handlers: map[string]core.IHandler{}}
+	group := newGroup(router)
+
+	group.Use(panicking)
+	handler := &plainHandler{}
+	group.GET("/plain", handler)
+
+	if len(router.middlewares) != 1 || funcName(router.middlewares[0]) != "middleware.Recover" {
+		t.Fatalf("expected the group middleware to be wrapped by middleware.Recover, got %d middlewares", len(router.middlewares))
+	}
+
+	route := (*group.table)[0]
+	if len(route.Middlewares) != 1 || route.Middlewares[0] != "routes.panicking" {
+		t.Fatalf("expected the route table to keep the middleware name, got %v", route.Middlewares)
+	}
+
+	registered := router.handlers["GET /plain"]
+	if inner, _ := middleware.Unwrap(registered); registered == core.IHandler(handler) || inner != core.IHandler(handler) {
+		t.Fatal("expected the handler to be registered with panic recovery")
+	}
+}
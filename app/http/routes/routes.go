@@ -1,10 +1,42 @@
 package routes
 
 import (
+	"gfly/app/http/middleware"
 	"github.com/gflydev/core"
 )
 
 func Router(r core.IFly) {
-	ApiRoutes(r) // Register API routes.
-	WebRoutes(r) // Register Web routes.
+	group := newGroup(r)
+	register(group)
+
+	// Keep mounted routes for `route:list` and debug endpoint
+	mountedLock.Lock()
+	mounted = *group.table
+	mountedLock.Unlock()
+
+	// Label metrics, access logs and spans by route pattern
+	patterns := make([]string, 0, len(*group.table))
+	for _, route := range *group.table {
+		patterns = append(patterns, route.Path)
+	}
+	middleware.SetRoutePatterns(patterns)
+}
+
+// register register global middlewares and all routes into group.
+func register(r *Group) {
+	// Global middlewares
+	r.Use(
+		middleware.InFlight(),
+		middleware.RequestID(),
+		middleware.AccessLog(),
+		middleware.Metrics(),
+		middleware.Tracing(),
+		middleware.SecurityHeaders(),
+		middleware.CORS(),
+		middleware.CSRF(append([]string{apiPrefix()}, systemPaths...)...), // API routes use bearer tokens
+	)
+
+	SystemRoutes(r) // Register probe routes.
+	ApiRoutes(r)    // Register API routes.
+	WebRoutes(r)    // Register Web routes.
 }
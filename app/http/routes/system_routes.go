@@ -0,0 +1,34 @@
+package routes
+
+import (
+	"gfly/app/http/controllers/api"
+	"gfly/app/http/middleware"
+	"gfly/app/http/response"
+	"gfly/app/metrics"
+	"github.com/gflydev/core/utils"
+)
+
+// systemPaths probe paths which are excluded from sessions and CSRF.
+var systemPaths = []string{"/healthz", "/readyz", "/metrics", "/debug"}
+
+// SystemRoutes func for describe probe routes of orchestrators and load balancers.
+func SystemRoutes(r *Group) {
+	// curl -v -X GET http://localhost:7789/healthz | jq
+	r.GET("/healthz", middleware.Apply(api.NewHealthApi()))
+	// curl -v -X GET http://localhost:7789/readyz | jq
+	r.GET("/readyz", middleware.Apply(api.NewReadyApi()))
+
+	// Metrics are served here unless an admin port is set by METRICS_ADDR
+	if metrics.Enabled() && metrics.AdminAddress() == "" {
+		// curl -v -X GET http://localhost:7789/metrics -H 'Authorization: Bearer <METRICS_TOKEN>'
+		r.GET("/metrics", middleware.Apply(api.NewMetricsApi()))
+	}
+
+	// Routes table is only exposed in debug mode
+	if utils.Getenv("APP_DEBUG", false) {
+		// curl -v -X GET 'http://localhost:7789/debug/routes?method=GET&prefix=/api' | jq
+		r.GET("/debug/routes", middleware.Apply(api.NewRoutesApi(func(method, prefix string) []response.Route {
+			return Filter(Mounted(), method, prefix)
+		})))
+	}
+}
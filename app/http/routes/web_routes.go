@@ -2,11 +2,11 @@ package routes
 
 import (
 	"gfly/app/http/controllers/page"
-	"github.com/gflydev/core"
+	"gfly/app/http/middleware"
 )
 
 // WebRoutes func for describe a group of Web page routes.
-func WebRoutes(r core.IFlyRouter) {
+func WebRoutes(r *Group) {
 	// Web Routers
-	r.GET("/", page.NewHomePage())
+	r.GET("/", middleware.Apply(page.NewHomePage()))
 }
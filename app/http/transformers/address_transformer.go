@@ -0,0 +1,37 @@
+package transformers
+
+import (
+	"gfly/app/domain/models"
+	"gfly/app/http/response"
+)
+
+// ToAddressResponse convert an address model to response.
+func ToAddressResponse(address models.Address) response.Address {
+	return response.Address{
+		ID:           address.ID,
+		UserID:       address.UserID,
+		Type:         address.Type,
+		IsDefault:    address.IsDefault,
+		AddressLine1: address.AddressLine1,
+		AddressLine2: address.AddressLine2.String,
+		Ward:         address.Ward.String,
+		District:     address.District.String,
+		City:         address.City.String,
+		State:        address.State.String,
+		Country:      address.Country.String,
+		CreatedAt:    address.CreatedAt,
+		UpdatedAt:    nullTime(address.UpdatedAt),
+	}
+}
+
+// ToListAddressResponse convert address models to response.
+func ToListAddressResponse(addresses []models.Address) response.ListAddress {
+	data := make([]response.Address, 0, len(addresses))
+	for _, address := range addresses {
+		data = append(data, ToAddressResponse(address))
+	}
+
+	return response.ListAddress{
+		Data: data,
+	}
+}
@@ -0,0 +1,61 @@
+package transformers
+
+import (
+	"database/sql"
+	"gfly/app/domain/models"
+	"gfly/app/domain/repository"
+	"gfly/app/http/response"
+	"time"
+)
+
+// ToUserResponse convert a user model to response. Fields `password` and `token` are dropped.
+func ToUserResponse(user models.User) response.User {
+	return toUserResponse(user, repository.Pool.GetRolesByUserID(user.ID))
+}
+
+// toUserResponse convert a user model and its roles to response.
+func toUserResponse(user models.User, userRoles []models.Role) response.User {
+	roles := make([]string, 0, len(userRoles))
+	for _, role := range userRoles {
+		roles = append(roles, role.Slug)
+	}
+
+	return response.User{
+		ID:           user.ID,
+		Email:        user.Email,
+		Fullname:     user.Fullname,
+		Phone:        user.Phone,
+		Avatar:       user.Avatar.String,
+		Status:       user.Status,
+		Roles:        roles,
+		CreatedAt:    user.CreatedAt,
+		UpdatedAt:    user.UpdatedAt,
+		VerifiedAt:   nullTime(user.VerifiedAt),
+		BlockedAt:    nullTime(user.BlockedAt),
+		LastAccessAt: nullTime(user.LastAccessAt),
+		DeletedAt:    nullTime(user.DeletedAt),
+	}
+}
+
+// ToListUserResponse convert a page of user models to response.
+// Roles are keyed by user ID, see repository.GetRolesByUserIDs.
+func ToListUserResponse(users []models.User, roles map[int][]models.Role, page, perPage, total int) response.ListUser {
+	data := make([]response.User, 0, len(users))
+	for _, user := range users {
+		data = append(data, toUserResponse(user, roles[user.ID]))
+	}
+
+	return response.ListUser{
+		Meta: response.NewMeta(page, perPage, total),
+		Data: data,
+	}
+}
+
+// nullTime convert a nullable time to pointer.
+func nullTime(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+
+	return &t.Time
+}
@@ -0,0 +1,169 @@
+package lifecycle
+
+import (
+	"context"
+	"fmt"
+	"gfly/app/connections"
+	"gfly/app/domain/repository"
+	"gfly/app/services"
+	"gfly/app/tracing"
+	"github.com/gflydev/core/log"
+	"github.com/gflydev/core/utils"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// ====================================================================
+// ============================ Data Types ============================
+// ====================================================================
+
+// Hook a shutdown phase. It should return when `ctx` is done, the shutdown deadline was reached.
+type Hook func(ctx context.Context) error
+
+// phase a named shutdown hook.
+type phase struct {
+	name string
+	hook Hook
+}
+
+// Server an application server which can stop its listener, like *fasthttp.Server. ShutdownWithContext
+// closes the listener and idle connections, then waits for open connections until `ctx` is done.
+type Server interface {
+	ShutdownWithContext(ctx context.Context) error
+}
+
+// Manager run shutdown phases of a process in order when it receives SIGINT or SIGTERM.
+// All phases share one deadline given by env SHUTDOWN_TIMEOUT (seconds).
+type Manager struct {
+	timeout time.Duration
+	phases  []phase
+	once    sync.Once
+}
+
+// New create a lifecycle manager.
+//
+//	manager := lifecycle.New()
+//	manager.OnShutdown("queue worker", worker.Shutdown)
+//	manager.CloseResources()
+//	manager.Wait()
+func New() *Manager {
+	return &Manager{
+		timeout: Timeout(),
+	}
+}
+
+// Timeout get the shutdown deadline of env SHUTDOWN_TIMEOUT (seconds, default 30).
+func Timeout() time.Duration {
+	return time.Duration(utils.Getenv("SHUTDOWN_TIMEOUT", 30)) * time.Second
+}
+
+// ====================================================================
+// ============================== Phases ==============================
+// ====================================================================
+
+// OnShutdown add a phase. Phases run in the order they were added.
+func (m *Manager) OnShutdown(name string, hook Hook) {
+	m.phases = append(m.phases, phase{name: name, hook: hook})
+}
+
+// Drain add phases of an application server: make readiness fail, wait env SHUTDOWN_DRAIN_DELAY
+// (seconds, default 5) for load balancers to stop sending traffic, stop the listener of `app` then
+// wait for in-flight requests. The listener is stopped when `app` implements Server.
+func (m *Manager) Drain(app any, waitInFlight Hook) {
+	m.OnShutdown("readiness", func(ctx context.Context) error {
+		services.MarkShuttingDown()
+
+		select {
+		case <-time.After(time.Duration(utils.Getenv("SHUTDOWN_DRAIN_DELAY", 5)) * time.Second):
+		case <-ctx.Done():
+		}
+
+		return nil
+	})
+
+	m.OnShutdown("listener", func(ctx context.Context) error {
+		server, ok := app.(Server)
+		if !ok {
+			return fmt.Errorf("%T can not stop its listener, it stays open until exit", app)
+		}
+
+		return server.ShutdownWithContext(ctx)
+	})
+
+	m.OnShutdown("in-flight requests", waitInFlight)
+}
+
+// CloseResources add phases closing shared resources, in order: export pending spans, close the Redis
+// connection pools of cache, session and queue client then the database pool. Add them after phases
+// stopping the work using them.
+func (m *Manager) CloseResources() {
+	m.OnShutdown("tracing", func(ctx context.Context) error {
+		tracing.Shutdown(ctx)
+
+		return nil
+	})
+
+	m.OnShutdown("cache redis", func(_ context.Context) error {
+		return connections.CloseCacheRedis()
+	})
+
+	m.OnShutdown("session redis", func(_ context.Context) error {
+		return connections.CloseSessionRedis()
+	})
+
+	m.OnShutdown("queue client", func(_ context.Context) error {
+		return connections.CloseQueue()
+	})
+
+	m.OnShutdown("database", func(_ context.Context) error {
+		return repository.Close()
+	})
+}
+
+// ====================================================================
+// ============================= Shutdown =============================
+// ====================================================================
+
+// Wait block until SIGINT or SIGTERM, then Shutdown. A second signal exits immediately.
+func (m *Manager) Wait() {
+	signals := make(chan os.Signal, 2)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+
+	sig := <-signals
+	log.Infof("Received %v, shutting down within %v", sig, m.timeout)
+
+	go func() {
+		sig := <-signals
+		log.Errorf("Received %v again, exit immediately", sig)
+		os.Exit(1)
+	}()
+
+	m.Shutdown()
+}
+
+// Shutdown run all phases in order within the shutdown deadline, logging each one. A failed phase
+// does not stop the next ones. Later calls do nothing.
+func (m *Manager) Shutdown() {
+	m.once.Do(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), m.timeout)
+		defer cancel()
+
+		for _, p := range m.phases {
+			start := time.Now()
+			log.Infof("Shutdown :: %s", p.name)
+
+			if err := p.hook(ctx); err != nil {
+				log.Errorf("Shutdown :: %s failed after %v: %v", p.name, time.Since(start), err)
+
+				continue
+			}
+
+			log.Infof("Shutdown :: %s done in %v", p.name, time.Since(start))
+		}
+
+		log.Infof("Shutdown completed")
+	})
+}
@@ -0,0 +1,65 @@
+package lifecycle
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+)
+
+// fakeServer a Server recording when its listener was stopped.
+type fakeServer struct {
+	calls *[]string
+}
+
+// ShutdownWithContext implement Server.
+func (s fakeServer) ShutdownWithContext(_ context.Context) error {
+	*s.calls = append(*s.calls, "listener")
+
+	return nil
+}
+
+func TestDrainStopsListenerBeforeInFlight(t *testing.T) {
+	t.Setenv("SHUTDOWN_DRAIN_DELAY", "0")
+
+	var calls []string
+	m := &Manager{timeout: time.Second}
+	m.Drain(fakeServer{calls: &calls}, func(_ context.Context) error {
+		calls = append(calls, "in-flight requests")
+
+		return nil
+	})
+	m.Shutdown()
+
+	want := []string{"listener", "in-flight requests"}
+	if !slices.Equal(calls, want) {
+		t.Fatalf("expected %v, got %v", want, calls)
+	}
+
+	var names []string
+	for _, p := range m.phases {
+		names = append(names, p.name)
+	}
+
+	wantNames := []string{"readiness", "listener", "in-flight requests"}
+	if !slices.Equal(names, wantNames) {
+		t.Fatalf("expected phases %v, got %v", wantNames, names)
+	}
+}
+
+func TestDrainWithoutServerStillWaitsInFlight(t *testing.T) {
+	t.Setenv("SHUTDOWN_DRAIN_DELAY", "0")
+
+	waited := false
+	m := &Manager{timeout: time.Second}
+	m.Drain(struct{}{}, func(_ context.Context) error {
+		waited = true
+
+		return nil
+	})
+	m.Shutdown()
+
+	if !waited {
+		t.Fatal("expected in-flight requests to be waited when the listener can not be stopped")
+	}
+}
@@ -0,0 +1,288 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"gfly/app/domain/repository"
+	"github.com/gflydev/cache"
+	"github.com/gflydev/console"
+	"github.com/gflydev/core/log"
+	"github.com/gflydev/core/utils"
+	"github.com/hibiken/asynq"
+	"github.com/redis/go-redis/v9"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ====================================================================
+// =============================== HTTP ===============================
+// ====================================================================
+
+var (
+	httpRequests = NewCounterVec(
+		"http_requests_total",
+		"Number of HTTP requests by method, route pattern and status.",
+		"method", "route", "status",
+	)
+	httpDuration = NewHistogramVec(
+		"http_request_duration_seconds",
+		"Latency of HTTP requests by method and route pattern.",
+		nil, "method", "route",
+	)
+)
+
+// ObserveRequest record a served HTTP request.
+func ObserveRequest(method, route string, status int, duration time.Duration) {
+	httpRequests.Inc(method, route, strconv.Itoa(status))
+	httpDuration.Observe(duration.Seconds(), method, route)
+}
+
+// ====================================================================
+// ============================= Database =============================
+// ====================================================================
+
+// Database connection pool stats. See repository.Driver.
+func init() {
+	NewGaugeFunc("db_pool_connections", "Database pool connections by state.", func() []Sample {
+		stats, ok := repository.Stats()
+		if !ok {
+			return nil
+		}
+
+		return []Sample{
+			{Labels: []string{"open"}, Value: float64(stats.OpenConnections)},
+			{Labels: []string{"in_use"}, Value: float64(stats.InUse)},
+			{Labels: []string{"idle"}, Value: float64(stats.Idle)},
+			{Labels: []string{"max_open"}, Value: float64(stats.MaxOpenConnections)},
+		}
+	}, "state")
+
+	NewGaugeFunc("db_pool_wait_total", "Total number of connections waited for.", func() []Sample {
+		stats, ok := repository.Stats()
+		if !ok {
+			return nil
+		}
+
+		return []Sample{{Value: float64(stats.WaitCount)}}
+	})
+
+	NewGaugeFunc("db_pool_wait_seconds_total", "Total time blocked waiting for a new connection.", func() []Sample {
+		stats, ok := repository.Stats()
+		if !ok {
+			return nil
+		}
+
+		return []Sample{{Value: stats.WaitDuration.Seconds()}}
+	})
+}
+
+// ====================================================================
+// =============================== Cache ==============================
+// ====================================================================
+
+// Cache operation results.
+const (
+	cacheHit   = "hit"
+	cacheMiss  = "miss"
+	cacheError = "error"
+)
+
+var cacheRequests = NewCounterVec(
+	"cache_requests_total",
+	"Number of cache operations by operation and result.",
+	"operation", "result",
+)
+
+// Cache wrap a cache driver to count hits and misses.
+//
+//	cache.Register(metrics.Cache(connections.Cache()))
+func Cache(driver cache.ICache) cache.ICache {
+	return &cacheDriver{driver}
+}
+
+// cacheDriver a cache driver counting its operations.
+type cacheDriver struct {
+	cache.ICache
+}
+
+// Set implement cache.ICache.
+func (d *cacheDriver) Set(key string, value any, expiration time.Duration) error {
+	err := d.ICache.Set(key, value, expiration)
+	cacheRequests.Inc("set", cacheResult(err, "ok"))
+
+	return err
+}
+
+// Get implement cache.ICache.
+func (d *cacheDriver) Get(key string) (any, error) {
+	value, err := d.ICache.Get(key)
+	cacheRequests.Inc("get", cacheResult(err, cacheHit))
+
+	return value, err
+}
+
+// Del implement cache.ICache.
+func (d *cacheDriver) Del(key string) error {
+	err := d.ICache.Del(key)
+	cacheRequests.Inc("del", cacheResult(err, "ok"))
+
+	return err
+}
+
+// cacheResult get result label of a cache operation.
+func cacheResult(err error, success string) string {
+	switch {
+	case err == nil:
+		return success
+	case errors.Is(err, redis.Nil):
+		return cacheMiss
+	}
+
+	return cacheError
+}
+
+// ====================================================================
+// =============================== Queue ==============================
+// ====================================================================
+
+var (
+	taskRuns = NewCounterVec(
+		"queue_tasks_processed_total",
+		"Number of queue tasks processed by task and outcome.",
+		"task", "outcome",
+	)
+	taskDuration = NewHistogramVec(
+		"queue_task_duration_seconds",
+		"Processing time of queue tasks.",
+		nil, "task",
+	)
+)
+
+// Task wrap a queue task to record its outcomes and durations.
+//
+//	console.RegisterTask(metrics.Task("hello-world", &HelloTask{}), "hello-world")
+func Task(name string, task console.ITask) console.ITask {
+	return &queueTask{name: name, ITask: task}
+}
+
+// queueTask a queue task recording its runs.
+type queueTask struct {
+	console.ITask
+	name string
+}
+
+// Dequeue implement console.ITask.
+func (t *queueTask) Dequeue(ctx context.Context, task *asynq.Task) error {
+	start := time.Now()
+	err := t.ITask.Dequeue(ctx, task)
+
+	outcome := "success"
+	if err != nil {
+		outcome = "failure"
+	}
+
+	taskRuns.Inc(t.name, outcome)
+	taskDuration.Observe(time.Since(start).Seconds(), t.name)
+
+	return err
+}
+
+var (
+	queueInspector     *asynq.Inspector
+	queueInspectorOnce sync.Once
+)
+
+// Queue depths by state, read from the queue Redis database at scrape time.
+func init() {
+	NewGaugeFunc("queue_tasks", "Number of tasks in queues by state.", func() []Sample {
+		queueInspectorOnce.Do(func() {
+			queueInspector = asynq.NewInspector(asynq.RedisClientOpt{
+				Addr: fmt.Sprintf(
+					"%s:%d",
+					utils.Getenv("REDIS_HOST", "localhost"),
+					utils.Getenv("REDIS_PORT", 6379),
+				),
+				Password: utils.Getenv("REDIS_PASSWORD", ""),
+				DB:       utils.Getenv("REDIS_QUEUE_NUMBER", 0),
+			})
+		})
+
+		queues, err := queueInspector.Queues()
+		if err != nil {
+			log.Warnf("Could not inspect queues: %v", err)
+
+			return nil
+		}
+
+		var samples []Sample
+		for _, queue := range queues {
+			info, err := queueInspector.GetQueueInfo(queue)
+			if err != nil {
+				continue
+			}
+
+			samples = append(samples,
+				Sample{Labels: []string{queue, "pending"}, Value: float64(info.Pending)},
+				Sample{Labels: []string{queue, "active"}, Value: float64(info.Active)},
+				Sample{Labels: []string{queue, "scheduled"}, Value: float64(info.Scheduled)},
+				Sample{Labels: []string{queue, "retry"}, Value: float64(info.Retry)},
+				Sample{Labels: []string{queue, "archived"}, Value: float64(info.Archived)},
+			)
+		}
+
+		return samples
+	}, "queue", "state")
+}
+
+// ====================================================================
+// ============================= Scheduler ============================
+// ====================================================================
+
+var (
+	jobRuns = NewCounterVec(
+		"scheduler_job_runs_total",
+		"Number of scheduled job runs by job and outcome.",
+		"job", "outcome",
+	)
+	jobDuration = NewHistogramVec(
+		"scheduler_job_duration_seconds",
+		"Running time of scheduled jobs.",
+		[]float64{0.01, 0.1, 0.5, 1, 5, 10, 30, 60, 300},
+		"job",
+	)
+)
+
+// Job wrap a scheduled job to record its runs and durations.
+//
+//	console.RegisterJob(metrics.Job(&HelloJob{}))
+func Job(job console.IJob) console.IJob {
+	name := fmt.Sprintf("%T", job)
+
+	return &scheduledJob{
+		IJob: job,
+		name: name[strings.LastIndex(name, ".")+1:],
+	}
+}
+
+// scheduledJob a scheduled job recording its runs.
+type scheduledJob struct {
+	console.IJob
+	name string
+}
+
+// Handle implement console.IJob. A panic is recorded as a failure then re-thrown.
+func (j *scheduledJob) Handle() {
+	start := time.Now()
+	outcome := "failure"
+
+	defer func() {
+		jobRuns.Inc(j.name, outcome)
+		jobDuration.Observe(time.Since(start).Seconds(), j.name)
+	}()
+
+	j.IJob.Handle()
+	outcome = "success"
+}
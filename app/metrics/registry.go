@@ -0,0 +1,310 @@
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ====================================================================
+// ============================= Registry =============================
+// ====================================================================
+
+// Collector a metric family written in Prometheus text format.
+type Collector interface {
+	// Collect write the metric family to `w`.
+	Collect(w io.Writer)
+}
+
+var (
+	collectors     []Collector
+	collectorsLock sync.RWMutex
+)
+
+// Register add a collector to the registry. Metrics are written in registration order.
+func Register(collector Collector) {
+	collectorsLock.Lock()
+	defer collectorsLock.Unlock()
+
+	collectors = append(collectors, collector)
+}
+
+// Write write all registered metrics in Prometheus text format (version 0.0.4).
+func Write(w io.Writer) {
+	collectorsLock.RLock()
+	defer collectorsLock.RUnlock()
+
+	for _, collector := range collectors {
+		collector.Collect(w)
+	}
+}
+
+// ====================================================================
+// ============================= Counter ==============================
+// ====================================================================
+
+// CounterVec a monotonic counter partitioned by labels.
+type CounterVec struct {
+	name   string
+	help   string
+	labels []string
+	mutex  sync.Mutex
+	values map[string]float64
+}
+
+// NewCounterVec create and register a counter.
+//
+//	var jobs = metrics.NewCounterVec("jobs_total", "Number of jobs.", "job", "outcome")
+//	jobs.Inc("HelloJob", "success")
+func NewCounterVec(name, help string, labels ...string) *CounterVec {
+	c := &CounterVec{
+		name:   name,
+		help:   help,
+		labels: labels,
+		values: make(map[string]float64),
+	}
+	Register(c)
+
+	return c
+}
+
+// Inc increase counter of given label values by 1.
+func (c *CounterVec) Inc(values ...string) {
+	c.Add(1, values...)
+}
+
+// Add increase counter of given label values by `delta`.
+func (c *CounterVec) Add(delta float64, values ...string) {
+	key := seriesKey(values)
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.values[key] += delta
+}
+
+// Collect implement Collector.
+func (c *CounterVec) Collect(w io.Writer) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	writeHeader(w, c.name, c.help, "counter")
+	for _, key := range sortedKeys(c.values) {
+		writeSample(w, c.name, labelPairs(c.labels, splitKey(key)), c.values[key])
+	}
+}
+
+// ====================================================================
+// ============================ Histogram =============================
+// ====================================================================
+
+// DefaultBuckets upper bounds in seconds of latency histograms.
+var DefaultBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// HistogramVec a histogram of observations partitioned by labels.
+type HistogramVec struct {
+	name    string
+	help    string
+	labels  []string
+	buckets []float64
+	mutex   sync.Mutex
+	series  map[string]*histogram
+}
+
+// histogram observations of a label set.
+type histogram struct {
+	counts []uint64 // Cumulated count per bucket
+	count  uint64
+	sum    float64
+}
+
+// NewHistogramVec create and register a histogram. Nil `buckets` means DefaultBuckets.
+//
+//	var durations = metrics.NewHistogramVec("job_duration_seconds", "Job durations.", nil, "job")
+//	durations.Observe(time.Since(start).Seconds(), "HelloJob")
+func NewHistogramVec(name, help string, buckets []float64, labels ...string) *HistogramVec {
+	if buckets == nil {
+		buckets = DefaultBuckets
+	}
+
+	h := &HistogramVec{
+		name:    name,
+		help:    help,
+		labels:  labels,
+		buckets: buckets,
+		series:  make(map[string]*histogram),
+	}
+	Register(h)
+
+	return h
+}
+
+// Observe add an observation for given label values.
+func (h *HistogramVec) Observe(value float64, values ...string) {
+	key := seriesKey(values)
+
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	s, ok := h.series[key]
+	if !ok {
+		s = &histogram{counts: make([]uint64, len(h.buckets))}
+		h.series[key] = s
+	}
+
+	for i, bound := range h.buckets {
+		if value <= bound {
+			s.counts[i]++
+		}
+	}
+	s.count++
+	s.sum += value
+}
+
+// Collect implement Collector.
+func (h *HistogramVec) Collect(w io.Writer) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	writeHeader(w, h.name, h.help, "histogram")
+	for _, key := range sortedKeys(h.series) {
+		s := h.series[key]
+		pairs := labelPairs(h.labels, splitKey(key))
+
+		for i, bound := range h.buckets {
+			writeSample(w, h.name+"_bucket", append(pairs, labelPair("le", formatFloat(bound))), float64(s.counts[i]))
+		}
+		writeSample(w, h.name+"_bucket", append(pairs, labelPair("le", "+Inf")), float64(s.count))
+		writeSample(w, h.name+"_sum", pairs, s.sum)
+		writeSample(w, h.name+"_count", pairs, float64(s.count))
+	}
+}
+
+// ====================================================================
+// ============================ Gauge Func ============================
+// ====================================================================
+
+// Sample a gauge value of a label set.
+type Sample struct {
+	Labels []string
+	Value  float64
+}
+
+// GaugeFunc a gauge whose samples are read at scrape time.
+type GaugeFunc struct {
+	name    string
+	help    string
+	labels  []string
+	collect func() []Sample
+}
+
+// NewGaugeFunc create and register a gauge reading its samples from `collect` at scrape time.
+//
+//	metrics.NewGaugeFunc("goroutines", "Number of goroutines.", func() []metrics.Sample {
+//		return []metrics.Sample{{Value: float64(runtime.NumGoroutine())}}
+//	})
+func NewGaugeFunc(name, help string, collect func() []Sample, labels ...string) *GaugeFunc {
+	g := &GaugeFunc{
+		name:    name,
+		help:    help,
+		labels:  labels,
+		collect: collect,
+	}
+	Register(g)
+
+	return g
+}
+
+// Collect implement Collector.
+func (g *GaugeFunc) Collect(w io.Writer) {
+	samples := g.collect()
+	if len(samples) == 0 {
+		return
+	}
+
+	writeHeader(w, g.name, g.help, "gauge")
+	for _, sample := range samples {
+		writeSample(w, g.name, labelPairs(g.labels, sample.Labels), sample.Value)
+	}
+}
+
+// ====================================================================
+// ============================== Helpers =============================
+// ====================================================================
+
+// keySeparator separator of label values in series keys.
+const keySeparator = "\xff"
+
+// seriesKey build key of a series from its label values.
+func seriesKey(values []string) string {
+	return strings.Join(values, keySeparator)
+}
+
+// splitKey get label values from a series key.
+func splitKey(key string) []string {
+	return strings.Split(key, keySeparator)
+}
+
+// sortedKeys get keys of a series map in a stable order.
+func sortedKeys[T any](series map[string]T) []string {
+	keys := make([]string, 0, len(series))
+	for key := range series {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// labelPairs format labels with their values as `name="value"` pairs.
+func labelPairs(labels, values []string) []string {
+	pairs := make([]string, 0, len(labels)+1)
+	for i, label := range labels {
+		value := ""
+		if i < len(values) {
+			value = values[i]
+		}
+
+		pairs = append(pairs, labelPair(label, value))
+	}
+
+	return pairs
+}
+
+// labelValueEscaper escape backslashes, quotes and line feeds of label values.
+var labelValueEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// labelPair format a `name="value"` pair.
+func labelPair(label, value string) string {
+	return label + `="` + labelValueEscaper.Replace(value) + `"`
+}
+
+// writeHeader write HELP and TYPE lines of a metric family.
+func writeHeader(w io.Writer, name, help, kind string) {
+	_, _ = fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind)
+}
+
+// writeSample write a sample line.
+func writeSample(w io.Writer, name string, pairs []string, value float64) {
+	if len(pairs) > 0 {
+		name += "{" + strings.Join(pairs, ",") + "}"
+	}
+
+	_, _ = fmt.Fprintf(w, "%s %s\n", name, formatFloat(value))
+}
+
+// formatFloat format a sample value.
+func formatFloat(value float64) string {
+	switch {
+	case math.IsInf(value, 1):
+		return "+Inf"
+	case math.IsInf(value, -1):
+		return "-Inf"
+	}
+
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
@@ -0,0 +1,81 @@
+package metrics
+
+import (
+	"crypto/subtle"
+	"github.com/gflydev/core/log"
+	"github.com/gflydev/core/utils"
+	"github.com/valyala/fasthttp"
+	"strings"
+)
+
+// ====================================================================
+// ============================== Server ==============================
+// ====================================================================
+
+// ContentType content type of Prometheus text format.
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// Enabled check metrics are enabled by env METRICS_ENABLED.
+func Enabled() bool {
+	return utils.Getenv("METRICS_ENABLED", true)
+}
+
+// AdminAddress get address of the admin server given by env METRICS_ADDR, like `:9090`.
+// Empty means metrics are served by the application server.
+func AdminAddress() string {
+	return utils.Getenv("METRICS_ADDR", "")
+}
+
+// Authorized check a scrape is allowed. When env METRICS_TOKEN is set, scrapers must send
+// header `Authorization: Bearer <token>`.
+func Authorized(authorization string) bool {
+	token := utils.Getenv("METRICS_TOKEN", "")
+	if token == "" {
+		return true
+	}
+
+	bearer, ok := strings.CutPrefix(authorization, "Bearer ")
+
+	return ok && subtle.ConstantTimeCompare([]byte(bearer), []byte(token)) == 1
+}
+
+// Handle write all metrics as response of a scrape.
+func Handle(ctx *fasthttp.RequestCtx) {
+	if !Authorized(string(ctx.Request.Header.Peek("Authorization"))) {
+		ctx.SetStatusCode(fasthttp.StatusUnauthorized)
+
+		return
+	}
+
+	ctx.SetContentType(ContentType)
+	Write(ctx)
+}
+
+// ListenAndServe start the admin server at AdminAddress serving `/metrics` in background.
+// It does nothing when metrics are disabled or no admin address is set.
+// The queue worker and the scheduler use it, they have no application server.
+//
+//	metrics.ListenAndServe()
+func ListenAndServe() {
+	address := AdminAddress()
+	if !Enabled() || address == "" {
+		return
+	}
+
+	go func() {
+		log.Infof("Serve metrics at %s/metrics", address)
+
+		err := fasthttp.ListenAndServe(address, func(ctx *fasthttp.RequestCtx) {
+			if string(ctx.Path()) != "/metrics" {
+				ctx.SetStatusCode(fasthttp.StatusNotFound)
+
+				return
+			}
+
+			Handle(ctx)
+		})
+		if err != nil {
+			log.Errorf("Could not serve metrics at %s: %v", address, err)
+		}
+	}()
+}
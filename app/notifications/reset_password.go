@@ -1,17 +1,30 @@
 package notifications
 
 import (
+	"github.com/gflydev/core"
+	"github.com/gflydev/core/utils"
 	notifyMail "github.com/gflydev/notification/mail"
+	"github.com/gflydev/view/pongo"
+	"time"
 )
 
+// ResetPassword notification to send a signed password reset link.
 type ResetPassword struct {
-	Email string
+	Email     string
+	Fullname  string
+	Link      string
+	ExpiresAt time.Time
 }
 
 func (n ResetPassword) ToEmail() notifyMail.Data {
 	return notifyMail.Data{
 		To:      n.Email,
 		Subject: "gFly - Reset password",
-		Body:    "New password was created",
+		Body: pongo.New().Parse("mails/reset_password", core.Data{
+			"app_name":   utils.Getenv("APP_NAME", "gFly"),
+			"fullname":   n.Fullname,
+			"link":       n.Link,
+			"expires_at": n.ExpiresAt,
+		}),
 	}
 }
@@ -0,0 +1,30 @@
+package notifications
+
+import (
+	"github.com/gflydev/core"
+	"github.com/gflydev/core/utils"
+	notifyMail "github.com/gflydev/notification/mail"
+	"github.com/gflydev/view/pongo"
+	"time"
+)
+
+// VerifyEmail notification to send a signed email verification link.
+type VerifyEmail struct {
+	Email     string
+	Fullname  string
+	Link      string
+	ExpiresAt time.Time
+}
+
+func (n VerifyEmail) ToEmail() notifyMail.Data {
+	return notifyMail.Data{
+		To:      n.Email,
+		Subject: "gFly - Verify email",
+		Body: pongo.New().Parse("mails/verify_email", core.Data{
+			"app_name":   utils.Getenv("APP_NAME", "gFly"),
+			"fullname":   n.Fullname,
+			"link":       n.Link,
+			"expires_at": n.ExpiresAt,
+		}),
+	}
+}
@@ -0,0 +1,115 @@
+package services
+
+import (
+	"database/sql"
+	"gfly/app/domain/models"
+	"gfly/app/domain/repository"
+	"gfly/app/dto"
+	"gfly/app/errors"
+	"time"
+)
+
+// ====================================================================
+// ============================ Data Types ============================
+// ====================================================================
+
+// Address errors
+var (
+	ErrAddressNotFound = errors.NotFound("Address not found")
+)
+
+// ====================================================================
+// ======================= Address Processing =========================
+// ====================================================================
+
+// CreateAddress create a new address for an existing user.
+func CreateAddress(createAddress *dto.CreateAddress) (*models.Address, error) {
+	if repository.Pool.GetUserByID(createAddress.UserID) == nil {
+		return nil, ErrUserNotFound
+	}
+
+	addressType := createAddress.Type
+	if addressType == "" {
+		addressType = models.AddressTypeAddress
+	}
+
+	address := &models.Address{
+		UserID:       createAddress.UserID,
+		Type:         addressType,
+		IsDefault:    createAddress.IsDefault,
+		AddressLine1: createAddress.AddressLine1,
+		AddressLine2: nullString(createAddress.AddressLine2),
+		Ward:         nullString(createAddress.Ward),
+		District:     nullString(createAddress.District),
+		City:         nullString(createAddress.City),
+		State:        nullString(createAddress.State),
+		Country:      nullString(createAddress.Country),
+		CreatedAt:    time.Now(),
+	}
+
+	if err := repository.Pool.CreateAddress(address); err != nil {
+		return nil, err
+	}
+
+	return address, nil
+}
+
+// UpdateAddress update an existing address of a user.
+func UpdateAddress(updateAddress *dto.UpdateAddress) (*models.Address, error) {
+	address := repository.Pool.GetAddressByID(updateAddress.UserID, updateAddress.ID)
+	if address == nil {
+		return nil, ErrAddressNotFound
+	}
+
+	if updateAddress.Type != "" && updateAddress.Type != address.Type {
+		address.Type = updateAddress.Type
+		// Moved address does not take default of new type unless asked
+		address.IsDefault = false
+	}
+	if updateAddress.IsDefault != nil {
+		address.IsDefault = *updateAddress.IsDefault
+	}
+	if updateAddress.AddressLine1 != "" {
+		address.AddressLine1 = updateAddress.AddressLine1
+	}
+	if updateAddress.AddressLine2 != "" {
+		address.AddressLine2 = nullString(updateAddress.AddressLine2)
+	}
+	if updateAddress.Ward != "" {
+		address.Ward = nullString(updateAddress.Ward)
+	}
+	if updateAddress.District != "" {
+		address.District = nullString(updateAddress.District)
+	}
+	if updateAddress.City != "" {
+		address.City = nullString(updateAddress.City)
+	}
+	if updateAddress.State != "" {
+		address.State = nullString(updateAddress.State)
+	}
+	if updateAddress.Country != "" {
+		address.Country = nullString(updateAddress.Country)
+	}
+	address.UpdatedAt = sql.NullTime{Time: time.Now(), Valid: true}
+
+	if err := repository.Pool.UpdateAddress(address); err != nil {
+		return nil, err
+	}
+
+	return address, nil
+}
+
+// DeleteAddress soft delete an existing address of a user.
+func DeleteAddress(userID, addressID int) error {
+	address := repository.Pool.GetAddressByID(userID, addressID)
+	if address == nil {
+		return ErrAddressNotFound
+	}
+
+	return repository.Pool.DeleteAddress(address)
+}
+
+// nullString convert an optional string to nullable string.
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
@@ -0,0 +1,148 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"gfly/app/constant"
+	"gfly/app/domain/models"
+	"gfly/app/domain/repository"
+	"gfly/app/dto"
+	"gfly/app/errors"
+	"github.com/gflydev/core/log"
+	"github.com/gflydev/core/utils"
+	"golang.org/x/crypto/bcrypt"
+	"strconv"
+	"time"
+)
+
+// ====================================================================
+// ============================ Data Types ============================
+// ====================================================================
+
+// Authentication errors
+var (
+	ErrInvalidCredentials = errors.Unauthorized("Invalid email or password").WithCode(constant.ErrCodeInvalidCredentials)
+	ErrUserBlocked        = errors.Forbidden("User was blocked").WithCode(constant.ErrCodeUserBlocked)
+	ErrUserPending        = errors.Forbidden("User is pending for activation").WithCode(constant.ErrCodeUserPending)
+)
+
+// dummyHash used to keep constant response time when user does not exist.
+const dummyHash = "$2a$04$9QD944312deeQjnxF.zNauGx7NQ0GtS.xJhLy.zWqWxOE8B/XCN9i"
+
+// ====================================================================
+// ========================= Auth Processing ==========================
+// ====================================================================
+
+// SignIn verify user credentials then issue a new token pair.
+func SignIn(signIn *dto.SignIn) (*Tokens, error) {
+	user := repository.Pool.GetUserByEmail(signIn.Email)
+	if user == nil {
+		_ = bcrypt.CompareHashAndPassword([]byte(dummyHash), []byte(signIn.Password))
+
+		return nil, ErrInvalidCredentials
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(signIn.Password)); err != nil {
+		return nil, ErrInvalidCredentials
+	}
+
+	if err := CheckUserStatus(user); err != nil {
+		return nil, err
+	}
+
+	// Keep track last access
+	user.LastAccessAt = sql.NullTime{Time: time.Now(), Valid: true}
+	if err := repository.Pool.UpdateUser(user); err != nil {
+		log.Error(err)
+	}
+
+	return issueTokens(user.ID)
+}
+
+// SignUp register a new user in `pending` status with the default role.
+// The user is activated by verifying email.
+func SignUp(ctx context.Context, signUp *dto.SignUp) (*models.User, error) {
+	// Default role is given by the application, no administrator permission is needed
+	return insertUser(ctx, &dto.CreateUser{
+		RequestID: signUp.RequestID,
+		Email:     signUp.Email,
+		Password:  signUp.Password,
+		Fullname:  signUp.Fullname,
+		Phone:     signUp.Phone,
+		Status:    models.UserStatusPending,
+		Roles:     []string{utils.Getenv("DEFAULT_USER_ROLE", models.RoleMember.Name())},
+	})
+}
+
+// RefreshToken rotate given refresh token and issue a new token pair.
+func RefreshToken(refreshToken string) (*Tokens, error) {
+	userID, err := ConsumeRefreshToken(refreshToken)
+	if err != nil {
+		return nil, err
+	}
+
+	user := repository.Pool.GetUserByID(userID)
+	if user == nil {
+		return nil, ErrInvalidToken
+	}
+
+	if err = CheckUserStatus(user); err != nil {
+		return nil, err
+	}
+
+	return issueTokens(user.ID)
+}
+
+// SignOut revoke given access token and remove refresh token which belongs to the same user.
+func SignOut(accessToken, refreshToken string) error {
+	claims, err := ParseAccessToken(accessToken)
+	if err != nil {
+		return err
+	}
+
+	if err = RevokeAccessToken(claims); err != nil {
+		return err
+	}
+
+	if refreshToken == "" {
+		return nil
+	}
+
+	// Only owner can remove a refresh token
+	if userID, e := refreshTokenOwner(refreshToken); e == nil && strconv.Itoa(userID) == claims.Subject {
+		return DeleteRefreshToken(refreshToken)
+	}
+
+	return nil
+}
+
+// CheckUserStatus refuse blocked and pending users.
+func CheckUserStatus(user *models.User) error {
+	switch user.Status {
+	case models.UserStatusBlocked:
+		return ErrUserBlocked
+	case models.UserStatusPending:
+		return ErrUserPending
+	}
+
+	return nil
+}
+
+// issueTokens create access & refresh token pair for given user ID.
+func issueTokens(userID int) (*Tokens, error) {
+	access, expiresAt, err := CreateAccessToken(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	refresh, err := CreateRefreshToken(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Tokens{
+		Access:    access,
+		Refresh:   refresh,
+		ExpiresAt: expiresAt,
+	}, nil
+}
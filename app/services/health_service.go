@@ -0,0 +1,280 @@
+package services
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"gfly/app/connections"
+	"gfly/app/domain/repository"
+	"github.com/gflydev/cache"
+	"github.com/gflydev/core/errors"
+	"github.com/gflydev/core/utils"
+	"github.com/gflydev/session"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ====================================================================
+// ============================ Data Types ============================
+// ====================================================================
+
+// Health statuses
+const (
+	HealthUp           = "up"
+	HealthDown         = "down"
+	HealthShuttingDown = "shutting_down"
+)
+
+// HealthCheck check a dependency. It should give up when `ctx` is done.
+type HealthCheck func(ctx context.Context) error
+
+// HealthResult result of a dependency check. Only critical dependencies being down make readiness fail.
+type HealthResult struct {
+	Name     string
+	Status   string
+	Critical bool
+	Latency  time.Duration
+	Err      error
+}
+
+// healthCheck a registered dependency check.
+type healthCheck struct {
+	check    HealthCheck
+	critical bool
+}
+
+// ====================================================================
+// ========================= Check Registration =======================
+// ====================================================================
+
+var (
+	healthChecks     = make(map[string]healthCheck)
+	healthCheckNames []string
+	healthChecksLock sync.RWMutex
+	shuttingDown     atomic.Bool
+
+	// Last results of CheckReadiness, reused for env HEALTH_CHECK_CACHE
+	healthResults     []HealthResult
+	healthResultsAt   time.Time
+	healthResultsLock sync.Mutex
+)
+
+// Register default dependency checks. Mails are queued and retried, so SMTP being down is only reported.
+func init() {
+	RegisterHealthCheck("database", checkDatabase, true)
+	RegisterHealthCheck("redis", checkRedis, true)
+	RegisterHealthCheck("storage", checkStorage, true)
+	RegisterHealthCheck("smtp", checkSMTP, false)
+}
+
+// RegisterHealthCheck add a dependency check of readiness. A check with the same name is replaced.
+// Readiness fails when a `critical` dependency is down, other ones are only reported.
+func RegisterHealthCheck(name string, check HealthCheck, critical bool) {
+	healthChecksLock.Lock()
+	defer healthChecksLock.Unlock()
+
+	if _, ok := healthChecks[name]; !ok {
+		healthCheckNames = append(healthCheckNames, name)
+	}
+	healthChecks[name] = healthCheck{check: check, critical: critical}
+
+	healthResultsLock.Lock()
+	healthResults = nil
+	healthResultsLock.Unlock()
+}
+
+// MarkShuttingDown make readiness fail, so load balancers drain traffic before the server stops.
+func MarkShuttingDown() {
+	shuttingDown.Store(true)
+}
+
+// IsShuttingDown check the application is shutting down.
+func IsShuttingDown() bool {
+	return shuttingDown.Load()
+}
+
+// ====================================================================
+// ============================= Readiness ============================
+// ====================================================================
+
+// CheckReadiness get results of all dependency checks in registration order and whether all critical
+// dependencies are up. Results are reused for env HEALTH_CHECK_CACHE (milliseconds, default 1000) and
+// concurrent calls wait for the same run, so frequent probes do not load dependencies.
+func CheckReadiness() ([]HealthResult, bool) {
+	results := cachedHealthResults()
+
+	ready := !IsShuttingDown()
+	for _, result := range results {
+		if result.Critical {
+			ready = ready && result.Status == HealthUp
+		}
+	}
+
+	return results, ready
+}
+
+// cachedHealthResults get last results of dependency checks, running them again when they expired.
+func cachedHealthResults() []HealthResult {
+	healthResultsLock.Lock()
+	defer healthResultsLock.Unlock()
+
+	ttl := time.Duration(utils.Getenv("HEALTH_CHECK_CACHE", 1000)) * time.Millisecond
+	if healthResults != nil && time.Since(healthResultsAt) < ttl {
+		return healthResults
+	}
+
+	healthResults = runHealthChecks()
+	healthResultsAt = time.Now()
+
+	return healthResults
+}
+
+// runHealthChecks run all dependency checks concurrently. Each check is limited by env
+// HEALTH_CHECK_TIMEOUT (milliseconds), so all checks take at most one timeout.
+func runHealthChecks() []HealthResult {
+	healthChecksLock.RLock()
+	names := append([]string(nil), healthCheckNames...)
+	checks := make([]healthCheck, len(names))
+	for i, name := range names {
+		checks[i] = healthChecks[name]
+	}
+	healthChecksLock.RUnlock()
+
+	timeout := time.Duration(utils.Getenv("HEALTH_CHECK_TIMEOUT", 2000)) * time.Millisecond
+
+	results := make([]HealthResult, len(names))
+	var wg sync.WaitGroup
+	for i := range names {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i] = runHealthCheck(names[i], checks[i].check, timeout)
+			results[i].Critical = checks[i].critical
+		}(i)
+	}
+	wg.Wait()
+
+	return results
+}
+
+// runHealthCheck run a check with timeout and measure its latency.
+func runHealthCheck(name string, check HealthCheck, timeout time.Duration) HealthResult {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	start := time.Now()
+	done := make(chan error, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				done <- fmt.Errorf("%v", r)
+			}
+		}()
+		done <- check(ctx)
+	}()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = errors.New("timeout after %v", timeout)
+	}
+
+	result := HealthResult{
+		Name:    name,
+		Status:  HealthUp,
+		Latency: time.Since(start),
+		Err:     err,
+	}
+	if err != nil {
+		result.Status = HealthDown
+	}
+
+	return result
+}
+
+// ====================================================================
+// ============================== Checks ==============================
+// ====================================================================
+
+// checkDatabase ping the database.
+func checkDatabase(ctx context.Context) error {
+	return repository.Ping(ctx)
+}
+
+// checkRedis write then read a key of the Redis cache.
+func checkRedis(ctx context.Context) error {
+	client := connections.CacheRedis()
+	key := cache.Key("health:ping")
+
+	if err := client.Set(ctx, key, time.Now().UnixNano(), 10*time.Second).Err(); err != nil {
+		return err
+	}
+
+	return client.Get(ctx, key).Err()
+}
+
+// checkStorage create then remove a file in the application storage directory.
+func checkStorage(_ context.Context) error {
+	file, err := os.CreateTemp(utils.Getenv("APP_DIR", "storage/app"), ".health-*")
+	if err != nil {
+		return err
+	}
+
+	_ = file.Close()
+
+	return os.Remove(file.Name())
+}
+
+// checkSMTP connect to the SMTP server and wait for its greeting.
+func checkSMTP(ctx context.Context) error {
+	address := fmt.Sprintf("%s:%d", utils.Getenv("MAIL_HOST", "localhost"), utils.Getenv("MAIL_PORT", 587))
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	greeting, err := bufio.NewReader(conn).ReadString('\n')
+	if err != nil {
+		return err
+	}
+	if !strings.HasPrefix(greeting, "220") {
+		return errors.New("unexpected SMTP greeting %q", strings.TrimSpace(greeting))
+	}
+
+	_, _ = conn.Write([]byte("QUIT\r\n"))
+
+	return nil
+}
+
+// SessionHealthCheck create a check saving, reading and destroying a probe session of given provider.
+//
+//	provider := sessionRedis.New()
+//	session.Register(provider)
+//	services.RegisterHealthCheck("session", services.SessionHealthCheck(provider), true)
+func SessionHealthCheck(provider session.Provider) HealthCheck {
+	return func(_ context.Context) error {
+		id := []byte(fmt.Sprintf("health-%d", time.Now().UnixNano()))
+		if err := provider.Save(id, []byte("ping"), 10*time.Second); err != nil {
+			return err
+		}
+		if _, err := provider.Get(id); err != nil {
+			return err
+		}
+
+		return provider.Destroy(id)
+	}
+}
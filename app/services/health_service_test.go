@@ -0,0 +1,74 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+)
+
+// stubHealthChecks replace registered checks by given ones until the test ends.
+func stubHealthChecks(t *testing.T, register func()) {
+	checks, names := healthChecks, healthCheckNames
+	healthChecks, healthCheckNames = make(map[string]healthCheck), nil
+	register()
+
+	t.Cleanup(func() {
+		healthChecks, healthCheckNames = checks, names
+		healthResults = nil
+	})
+}
+
+func TestCheckReadinessIgnoresNonCriticalChecks(t *testing.T) {
+	t.Setenv("HEALTH_CHECK_CACHE", "0")
+
+	down := func(_ context.Context) error { return errors.New("connection refused") }
+	up := func(_ context.Context) error { return nil }
+
+	tests := []struct {
+		name      string
+		critical  bool
+		wantReady bool
+	}{
+		{"non-critical down", false, true},
+		{"critical down", true, false},
+	}
+
+	for _, tt := range tests {
+		stubHealthChecks(t, func() {
+			RegisterHealthCheck("database", up, true)
+			RegisterHealthCheck("smtp", down, tt.critical)
+		})
+
+		results, ready := CheckReadiness()
+		if ready != tt.wantReady {
+			t.Fatalf("%s: expected ready %v, got %v", tt.name, tt.wantReady, ready)
+		}
+		if len(results) != 2 || results[1].Status != HealthDown || results[1].Critical != tt.critical {
+			t.Fatalf("%s: expected smtp to be reported down, got %+v", tt.name, results)
+		}
+	}
+}
+
+func TestCheckReadinessCachesResults(t *testing.T) {
+	t.Setenv("HEALTH_CHECK_CACHE", "60000")
+
+	var runs atomic.Int32
+	stubHealthChecks(t, func() {
+		RegisterHealthCheck("database", func(_ context.Context) error {
+			runs.Add(1)
+
+			return nil
+		}, true)
+	})
+
+	for range 3 {
+		if _, ready := CheckReadiness(); !ready {
+			t.Fatal("expected ready")
+		}
+	}
+
+	if got := runs.Load(); got != 1 {
+		t.Fatalf("expected checks to run once, got %d", got)
+	}
+}
@@ -0,0 +1,131 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"gfly/app/console/queues"
+	"gfly/app/constant"
+	"gfly/app/domain/models"
+	"gfly/app/domain/repository"
+	"gfly/app/dto"
+	"gfly/app/errors"
+	"gfly/app/tracing"
+	"github.com/gflydev/cache"
+	"github.com/gflydev/core/log"
+	"github.com/gflydev/core/utils"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ====================================================================
+// ============================ Data Types ============================
+// ====================================================================
+
+// Password reset errors
+var (
+	ErrInvalidResetToken = errors.BadRequest("Invalid or expired reset token").WithCode(constant.ErrCodeInvalidToken)
+	ErrTooManyRequests   = errors.ErrRateLimited
+)
+
+// passwordResetThrottleKey cache key to throttle reset requests of an email.
+const passwordResetThrottleKey = "auth:forgot:%s"
+
+// ====================================================================
+// ======================= Password Processing ========================
+// ====================================================================
+
+// passwordResetTTL lifetime of a reset token.
+func passwordResetTTL() time.Duration {
+	return time.Duration(utils.Getenv("PASSWORD_RESET_TTL", 60)) * time.Minute
+}
+
+// passwordResetThrottle minimum interval between two reset requests of an email.
+func passwordResetThrottle() time.Duration {
+	return time.Duration(utils.Getenv("PASSWORD_RESET_THROTTLE", 60)) * time.Second
+}
+
+// passwordResetPath path of reset password page where the emailed link points to.
+func passwordResetPath() string {
+	return utils.Getenv("PASSWORD_RESET_PATH", "/reset-password")
+}
+
+// ForgotPassword email a signed reset link if given email belongs to an active or pending user.
+// The result does not tell whether the email exists.
+func ForgotPassword(ctx context.Context, forgotPassword *dto.ForgotPassword) error {
+	email := strings.ToLower(strings.TrimSpace(forgotPassword.Email))
+
+	// Throttle by email, no matter the email exists or not
+	throttleKey := fmt.Sprintf(passwordResetThrottleKey, hashToken(email))
+	if val, err := cache.Get(throttleKey); err == nil && val != nil {
+		return ErrTooManyRequests
+	}
+	if err := cache.Set(throttleKey, 1, passwordResetThrottle()); err != nil {
+		log.Error(err)
+	}
+
+	user := repository.Pool.GetUserByEmail(email)
+	if user == nil || user.Status == models.UserStatusBlocked {
+		return nil
+	}
+
+	token := randomToken(32)
+	now := time.Now()
+	expiresAt := now.Add(passwordResetTTL())
+
+	link, err := SignURL(passwordResetPath(), url.Values{"token": {token}}, expiresAt)
+	if err != nil {
+		return errors.Internal(err)
+	}
+
+	reset := &models.PasswordReset{
+		UserID:    user.ID,
+		Token:     hashToken(token),
+		ExpiresAt: expiresAt,
+		CreatedAt: now,
+	}
+	if err := repository.Pool.CreatePasswordReset(reset); err != nil {
+		return err
+	}
+
+	// Send email out of request processing
+	payload, name := queues.NewResetPasswordTask(forgotPassword.RequestID, user.Email, user.Fullname, link, expiresAt)
+	tracing.DispatchTask(ctx, payload, name)
+
+	return nil
+}
+
+// ResetPassword verify a signed reset token then set new password. The token can be used only once.
+func ResetPassword(resetPassword *dto.ResetPassword) error {
+	params := url.Values{
+		"token":         {resetPassword.Token},
+		signedExpires:   {strconv.FormatInt(resetPassword.Expires, 10)},
+		signedSignature: {resetPassword.Signature},
+	}
+	if err := VerifySignature(passwordResetPath(), params); err != nil {
+		return ErrInvalidResetToken
+	}
+
+	reset := repository.Pool.GetPasswordResetByToken(hashToken(resetPassword.Token))
+	if reset == nil || reset.UsedAt.Valid || time.Now().After(reset.ExpiresAt) {
+		return ErrInvalidResetToken
+	}
+
+	user := repository.Pool.GetUserByID(reset.UserID)
+	if user == nil || user.Status == models.UserStatusBlocked {
+		return ErrInvalidResetToken
+	}
+
+	hashedPassword, err := HashPassword(resetPassword.Password)
+	if err != nil {
+		return err
+	}
+
+	err = repository.Pool.ConsumePasswordReset(reset, hashedPassword)
+	if errors.Is(err, repository.ErrRecordConsumed) {
+		return ErrInvalidResetToken
+	}
+
+	return err
+}
@@ -0,0 +1,47 @@
+package services
+
+import (
+	"gfly/app/domain/models"
+	"gfly/app/domain/repository"
+	"gfly/app/errors"
+	"github.com/gflydev/core"
+	"slices"
+)
+
+// ====================================================================
+// ===================== Permission Processing ========================
+// ====================================================================
+
+// Authorize check given user has all permission slugs. Refused users get 403 error listing missing permissions.
+// Checking no permission always passes.
+//
+//	if err := services.Authorize(actorID, models.PermissionUsersBlock); err != nil {
+//		return nil, err
+//	}
+func Authorize(userID int, permissions ...string) error {
+	if len(permissions) == 0 {
+		return nil
+	}
+
+	if missing := MissingPermissions(repository.Pool.GetPermissionsByUserID(userID), permissions...); len(missing) > 0 {
+		return errors.ErrForbidden.WithDetails(core.Data{
+			"permissions": missing,
+		})
+	}
+
+	return nil
+}
+
+// MissingPermissions get required permission slugs which are not in given user permissions.
+func MissingPermissions(userPermissions []models.Permission, required ...string) []string {
+	var missing []string
+	for _, permission := range required {
+		if !slices.ContainsFunc(userPermissions, func(p models.Permission) bool {
+			return p.Slug == permission
+		}) {
+			missing = append(missing, permission)
+		}
+	}
+
+	return missing
+}
@@ -0,0 +1,223 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"gfly/app/connections"
+	"github.com/gflydev/cache"
+	"github.com/gflydev/core/log"
+	"github.com/gflydev/core/utils"
+	"github.com/redis/go-redis/v9"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// ====================================================================
+// ============================ Data Types ============================
+// ====================================================================
+
+// Rate limiter stores of env RATE_LIMIT_STORE.
+const (
+	RateLimitStoreRedis  = "redis"
+	RateLimitStoreMemory = "memory"
+)
+
+// rateLimitKey cache key of a rate limit bucket.
+const rateLimitKey = "ratelimit:%s:%s"
+
+// RateLimitResult result of a hit on a rate limit bucket.
+type RateLimitResult struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	ResetAfter time.Duration // Time until the oldest hit leaves the window
+}
+
+// IRateLimiter a sliding window rate limiter.
+type IRateLimiter interface {
+	// Hit record a hit on bucket `key` allowing `limit` hits per `window`.
+	Hit(key string, limit int, window time.Duration) (RateLimitResult, error)
+}
+
+// ====================================================================
+// ============================= Limiter ==============================
+// ====================================================================
+
+var (
+	rateLimiter     IRateLimiter
+	rateLimiterOnce sync.Once
+	memoryLimiter   = newMemoryRateLimiter()
+)
+
+// HitRateLimit record a hit on bucket `key` of policy `name`. The store is selected by env RATE_LIMIT_STORE,
+// `redis` (default) or `memory`. Hits fall back to the in-memory store while Redis is unavailable.
+func HitRateLimit(name, key string, limit int, window time.Duration) RateLimitResult {
+	rateLimiterOnce.Do(func() {
+		rateLimiter = memoryLimiter
+		if utils.Getenv("RATE_LIMIT_STORE", RateLimitStoreRedis) == RateLimitStoreRedis {
+			rateLimiter = newRedisRateLimiter()
+		}
+	})
+
+	bucket := fmt.Sprintf(rateLimitKey, name, key)
+
+	result, err := rateLimiter.Hit(bucket, limit, window)
+	if err != nil {
+		log.Warnf("Rate limiter falls back to memory store: %v", err)
+
+		result, _ = memoryLimiter.Hit(bucket, limit, window)
+	}
+
+	return result
+}
+
+// ====================================================================
+// =========================== Redis Store ============================
+// ====================================================================
+
+// slidingWindowScript keep hits of a bucket in a sorted set scored by time in milliseconds.
+// Return {allowed, count, oldest hit}.
+var slidingWindowScript = redis.NewScript(`
+local now = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local limit = tonumber(ARGV[3])
+
+redis.call('ZREMRANGEBYSCORE', KEYS[1], '-inf', now - window)
+
+local count = redis.call('ZCARD', KEYS[1])
+local allowed = 0
+if count < limit then
+	redis.call('ZADD', KEYS[1], now, ARGV[4])
+	count = count + 1
+	allowed = 1
+end
+redis.call('PEXPIRE', KEYS[1], window)
+
+local oldest = redis.call('ZRANGE', KEYS[1], 0, 0, 'WITHSCORES')
+return {allowed, count, tonumber(oldest[2] or now)}
+`)
+
+// redisRateLimiter a rate limiter storing buckets in the Redis cache database.
+// It shares the connection pool of the cache driver.
+type redisRateLimiter struct{}
+
+// newRedisRateLimiter create a rate limiter on the Redis cache database.
+func newRedisRateLimiter() *redisRateLimiter {
+	return &redisRateLimiter{}
+}
+
+// Hit implement IRateLimiter.
+func (l *redisRateLimiter) Hit(key string, limit int, window time.Duration) (RateLimitResult, error) {
+	now := time.Now()
+	member := strconv.FormatInt(now.UnixNano(), 10)
+
+	values, err := slidingWindowScript.Run(context.Background(), connections.CacheRedis(), []string{cache.Key(key)},
+		now.UnixMilli(), window.Milliseconds(), limit, member).Int64Slice()
+	if err != nil {
+		return RateLimitResult{}, err
+	}
+
+	oldest := time.UnixMilli(values[2])
+
+	return newRateLimitResult(values[0] == 1, limit, int(values[1]), window-now.Sub(oldest)), nil
+}
+
+// ====================================================================
+// =========================== Memory Store ===========================
+// ====================================================================
+
+// memorySweepInterval how often expired buckets are dropped from memory.
+const memorySweepInterval = time.Minute
+
+// memoryRateLimiter a rate limiter keeping buckets in process memory.
+// It is for local development and tests, limits are not shared between instances.
+type memoryRateLimiter struct {
+	mutex   sync.Mutex
+	buckets map[string]*memoryBucket
+	sweepAt time.Time
+}
+
+// memoryBucket hits of a bucket and the window of its policy.
+type memoryBucket struct {
+	hits   []time.Time
+	window time.Duration
+}
+
+// newMemoryRateLimiter create an in-memory rate limiter.
+func newMemoryRateLimiter() *memoryRateLimiter {
+	return &memoryRateLimiter{
+		buckets: make(map[string]*memoryBucket),
+	}
+}
+
+// Hit implement IRateLimiter.
+func (l *memoryRateLimiter) Hit(key string, limit int, window time.Duration) (RateLimitResult, error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	now := time.Now()
+	l.sweep(now)
+
+	bucket, ok := l.buckets[key]
+	if !ok {
+		bucket = &memoryBucket{}
+		l.buckets[key] = bucket
+	}
+	bucket.window = window
+
+	hits := inWindow(bucket.hits, now, window)
+
+	allowed := len(hits) < limit
+	if allowed {
+		hits = append(hits, now)
+	}
+	bucket.hits = hits
+
+	resetAfter := window
+	if len(hits) > 0 {
+		resetAfter = window - now.Sub(hits[0])
+	}
+
+	return newRateLimitResult(allowed, limit, len(hits), resetAfter), nil
+}
+
+// sweep drop buckets whose last hit left their own window, once per memorySweepInterval
+// to bound memory usage.
+func (l *memoryRateLimiter) sweep(now time.Time) {
+	if now.Before(l.sweepAt) {
+		return
+	}
+	l.sweepAt = now.Add(memorySweepInterval)
+
+	for key, bucket := range l.buckets {
+		if len(bucket.hits) == 0 || now.Sub(bucket.hits[len(bucket.hits)-1]) >= bucket.window {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+// ====================================================================
+// ============================== Helpers =============================
+// ====================================================================
+
+// inWindow get hits in the window ending at `now`.
+func inWindow(hits []time.Time, now time.Time, window time.Duration) []time.Time {
+	for i, hit := range hits {
+		if now.Sub(hit) < window {
+			return hits[i:]
+		}
+	}
+
+	return hits[:0]
+}
+
+// newRateLimitResult build a result from number of hits in window.
+func newRateLimitResult(allowed bool, limit, count int, resetAfter time.Duration) RateLimitResult {
+	return RateLimitResult{
+		Allowed:    allowed,
+		Limit:      limit,
+		Remaining:  max(limit-count, 0),
+		ResetAfter: max(resetAfter, 0),
+	}
+}
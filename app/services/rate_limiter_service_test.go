@@ -0,0 +1,128 @@
+package services
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMemoryRateLimiterWindow(t *testing.T) {
+	limiter := newMemoryRateLimiter()
+	window := 100 * time.Millisecond
+
+	tests := []struct {
+		name          string
+		wait          time.Duration
+		wantAllowed   bool
+		wantRemaining int
+	}{
+		{"first hit", 0, true, 2},
+		{"second hit", 0, true, 1},
+		{"third hit", 0, true, 0},
+		{"over limit", 0, false, 0},
+		{"still in window", window / 4, false, 0},
+		{"window slid", window, true, 2},
+	}
+
+	for _, tt := range tests {
+		time.Sleep(tt.wait)
+
+		result, err := limiter.Hit("login:127.0.0.1", 3, window)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tt.name, err)
+		}
+
+		if result.Allowed != tt.wantAllowed || result.Remaining != tt.wantRemaining {
+			t.Fatalf("%s: expected allowed=%v remaining=%d, got allowed=%v remaining=%d",
+				tt.name, tt.wantAllowed, tt.wantRemaining, result.Allowed, result.Remaining)
+		}
+
+		if result.Limit != 3 {
+			t.Fatalf("%s: expected limit 3, got %d", tt.name, result.Limit)
+		}
+
+		if result.ResetAfter <= 0 || result.ResetAfter > window {
+			t.Fatalf("%s: expected reset within window, got %v", tt.name, result.ResetAfter)
+		}
+	}
+}
+
+func TestMemoryRateLimiterBuckets(t *testing.T) {
+	limiter := newMemoryRateLimiter()
+
+	if result, _ := limiter.Hit("a", 1, time.Minute); !result.Allowed {
+		t.Fatal("expected first hit of bucket a to be allowed")
+	}
+
+	if result, _ := limiter.Hit("a", 1, time.Minute); result.Allowed {
+		t.Fatal("expected second hit of bucket a to be rejected")
+	}
+
+	if result, _ := limiter.Hit("b", 1, time.Minute); !result.Allowed {
+		t.Fatal("expected bucket b not to share hits of bucket a")
+	}
+}
+
+func TestMemoryRateLimiterSweep(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name    string
+		lastHit time.Duration // Time since the last hit
+		window  time.Duration
+		kept    bool
+	}{
+		{"long window, recent hit", time.Minute, time.Hour, true},
+		{"short window, expired hit", time.Minute, time.Second, false},
+		{"hit at window edge", time.Minute, time.Minute, false},
+		{"no hit", 0, time.Hour, false},
+	}
+
+	limiter := newMemoryRateLimiter()
+	for _, tt := range tests {
+		bucket := &memoryBucket{window: tt.window}
+		if tt.lastHit > 0 {
+			bucket.hits = []time.Time{now.Add(-tt.lastHit)}
+		}
+		limiter.buckets[tt.name] = bucket
+	}
+
+	// Each bucket is swept by its own window, whatever the window of the caller
+	limiter.sweep(now)
+
+	for _, tt := range tests {
+		if _, ok := limiter.buckets[tt.name]; ok != tt.kept {
+			t.Fatalf("%s: expected kept=%v, got %v", tt.name, tt.kept, ok)
+		}
+	}
+
+	// Next sweep waits for the sweep interval
+	limiter.buckets["late"] = &memoryBucket{window: time.Second}
+	limiter.sweep(now.Add(time.Second))
+	if _, ok := limiter.buckets["late"]; !ok {
+		t.Fatal("expected no sweep before the sweep interval")
+	}
+}
+
+func TestInWindow(t *testing.T) {
+	now := time.Now()
+	hits := []time.Time{now.Add(-3 * time.Second), now.Add(-2 * time.Second), now.Add(-time.Second)}
+
+	tests := []struct {
+		name   string
+		window time.Duration
+		want   int
+	}{
+		{"all", 5 * time.Second, 3},
+		{"recent", 1500 * time.Millisecond, 1},
+		{"edge excluded", 2 * time.Second, 1},
+		{"none", 500 * time.Millisecond, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := inWindow(hits, now, tt.window); len(got) != tt.want {
+				t.Fatalf("expected %d hits, got %d", tt.want, len(got))
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package services
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	stdErrors "errors"
+	"gfly/app/constant"
+	"gfly/app/errors"
+	"github.com/gflydev/core/log"
+	"github.com/gflydev/core/utils"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ====================================================================
+// ============================ Data Types ============================
+// ====================================================================
+
+// Signed URL errors
+var (
+	ErrInvalidSignature = errors.BadRequest("Invalid signature").WithCode(constant.ErrCodeInvalidToken)
+	ErrExpiredSignature = errors.BadRequest("Signature has expired").WithCode(constant.ErrCodeInvalidToken)
+)
+
+// ErrMissingAppKey APP_KEY is not set. URLs are never signed nor verified with an empty key.
+var ErrMissingAppKey = stdErrors.New("APP_KEY is not set")
+
+// Signed URL query parameters
+const (
+	signedExpires   = "expires"
+	signedSignature = "signature"
+)
+
+// ====================================================================
+// ============================ Signed URL ============================
+// ====================================================================
+
+// SignURL create an absolute URL of given path. Query parameters `expires` and `signature` are appended,
+// so the link can't be modified or used after it expires.
+//
+//	link, err := services.SignURL("/reset-password", url.Values{"token": {token}}, time.Now().Add(time.Hour))
+func SignURL(path string, params url.Values, expiresAt time.Time) (string, error) {
+	values := url.Values{}
+	for key, value := range params {
+		values[key] = value
+	}
+	values.Set(signedExpires, strconv.FormatInt(expiresAt.Unix(), 10))
+
+	sign, err := signature(path, values)
+	if err != nil {
+		return "", err
+	}
+	values.Set(signedSignature, sign)
+
+	return strings.TrimRight(utils.Getenv("APP_URL", ""), "/") + path + "?" + values.Encode(), nil
+}
+
+// VerifySignature check signature and expiration of query parameters of a signed URL of given path.
+func VerifySignature(path string, params url.Values) error {
+	expires, err := strconv.ParseInt(params.Get(signedExpires), 10, 64)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+
+	sign, err := signature(path, params)
+	if err != nil {
+		log.Error(err)
+
+		return ErrInvalidSignature
+	}
+
+	if !hmac.Equal([]byte(sign), []byte(params.Get(signedSignature))) {
+		return ErrInvalidSignature
+	}
+
+	if time.Now().Unix() > expires {
+		return ErrExpiredSignature
+	}
+
+	return nil
+}
+
+// appKey secret key to sign URLs.
+func appKey() ([]byte, error) {
+	return secretOf("APP_KEY", ErrMissingAppKey)
+}
+
+// signature compute HMAC of given path and query parameters, parameter `signature` is ignored.
+func signature(path string, params url.Values) (string, error) {
+	key, err := appKey()
+	if err != nil {
+		return "", err
+	}
+
+	values := url.Values{}
+	for key, value := range params {
+		if key != signedSignature {
+			values[key] = value
+		}
+	}
+
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(path + "?" + values.Encode()))
+
+	return hex.EncodeToString(mac.Sum(nil)), nil
+}
@@ -0,0 +1,191 @@
+package services
+
+import (
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// Keys of signed URLs in tests, long enough to be accepted.
+const (
+	testAppKey    = "0123456789abcdef0123456789abcdef"
+	rotatedAppKey = "fedcba9876543210fedcba9876543210"
+)
+
+func TestSignURL(t *testing.T) {
+	t.Setenv("APP_KEY", testAppKey)
+	t.Setenv("APP_URL", "https://gfly.dev/")
+
+	link, err := SignURL("/reset-password", url.Values{"token": {"abc"}}, time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	signed, err := url.Parse(link)
+	if err != nil {
+		t.Fatalf("invalid link %q: %v", link, err)
+	}
+
+	if signed.Scheme+"://"+signed.Host+signed.Path != "https://gfly.dev/reset-password" {
+		t.Fatalf("unexpected link %q", link)
+	}
+
+	if err = VerifySignature(signed.Path, signed.Query()); err != nil {
+		t.Fatalf("expected valid signature, got %v", err)
+	}
+}
+
+func TestVerifySignature(t *testing.T) {
+	t.Setenv("APP_KEY", testAppKey)
+
+	sign := func(path string, params url.Values, expiresAt time.Time) url.Values {
+		link, err := SignURL(path, params, expiresAt)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		signed, _ := url.Parse(link)
+
+		return signed.Query()
+	}
+
+	tests := []struct {
+		name   string
+		path   string
+		params func() url.Values
+		key    string // APP_KEY when verifying
+		want   error
+	}{
+		{
+			name: "valid",
+			path: "/reset-password",
+			params: func() url.Values {
+				return sign("/reset-password", url.Values{"token": {"abc"}}, time.Now().Add(time.Hour))
+			},
+			key:  testAppKey,
+			want: nil,
+		},
+		{
+			name: "expired",
+			path: "/reset-password",
+			params: func() url.Values {
+				return sign("/reset-password", url.Values{"token": {"abc"}}, time.Now().Add(-time.Minute))
+			},
+			key:  testAppKey,
+			want: ErrExpiredSignature,
+		},
+		{
+			name: "other path",
+			path: "/verify-email",
+			params: func() url.Values {
+				return sign("/reset-password", url.Values{"token": {"abc"}}, time.Now().Add(time.Hour))
+			},
+			key:  testAppKey,
+			want: ErrInvalidSignature,
+		},
+		{
+			name: "modified parameter",
+			path: "/reset-password",
+			params: func() url.Values {
+				params := sign("/reset-password", url.Values{"token": {"abc"}}, time.Now().Add(time.Hour))
+				params.Set("token", "abd")
+
+				return params
+			},
+			key:  testAppKey,
+			want: ErrInvalidSignature,
+		},
+		{
+			name: "extended expiration",
+			path: "/reset-password",
+			params: func() url.Values {
+				params := sign("/reset-password", url.Values{"token": {"abc"}}, time.Now().Add(-time.Minute))
+				params.Set(signedExpires, strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10))
+
+				return params
+			},
+			key:  testAppKey,
+			want: ErrInvalidSignature,
+		},
+		{
+			name: "missing expiration",
+			path: "/reset-password",
+			params: func() url.Values {
+				params := sign("/reset-password", url.Values{"token": {"abc"}}, time.Now().Add(time.Hour))
+				params.Del(signedExpires)
+
+				return params
+			},
+			key:  testAppKey,
+			want: ErrInvalidSignature,
+		},
+		{
+			name: "other key",
+			path: "/reset-password",
+			params: func() url.Values {
+				return sign("/reset-password", url.Values{"token": {"abc"}}, time.Now().Add(time.Hour))
+			},
+			key:  rotatedAppKey,
+			want: ErrInvalidSignature,
+		},
+		{
+			name: "empty key",
+			path: "/reset-password",
+			params: func() url.Values {
+				return sign("/reset-password", url.Values{"token": {"abc"}}, time.Now().Add(time.Hour))
+			},
+			key:  "",
+			want: ErrInvalidSignature,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("APP_KEY", testAppKey)
+			params := tt.params()
+
+			t.Setenv("APP_KEY", tt.key)
+
+			if err := VerifySignature(tt.path, params); err != tt.want {
+				t.Fatalf("expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestSignURLWithoutAppKey(t *testing.T) {
+	t.Setenv("APP_KEY", "")
+
+	if _, err := SignURL("/reset-password", url.Values{}, time.Now().Add(time.Hour)); err != ErrMissingAppKey {
+		t.Fatalf("expected %v, got %v", ErrMissingAppKey, err)
+	}
+
+	if err := CheckSecrets(); err == nil {
+		t.Fatal("expected CheckSecrets to fail without secrets")
+	}
+}
+
+func TestCheckSecrets(t *testing.T) {
+	tests := []struct {
+		name      string
+		jwtSecret string
+		appKey    string
+		valid     bool
+	}{
+		{"long secrets", rotatedAppKey, testAppKey, true},
+		{"empty JWT secret", "", testAppKey, false},
+		{"placeholder JWT secret", "secret", testAppKey, false},
+		{"placeholder app key", rotatedAppKey, "secret", false},
+		{"short app key", rotatedAppKey, testAppKey[:31], false},
+	}
+
+	for _, tt := range tests {
+		t.Setenv("JWT_SECRET_KEY", tt.jwtSecret)
+		t.Setenv("APP_KEY", tt.appKey)
+
+		if err := CheckSecrets(); (err == nil) != tt.valid {
+			t.Fatalf("%s: expected valid=%v, got error %v", tt.name, tt.valid, err)
+		}
+	}
+}
@@ -0,0 +1,264 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	stdErrors "errors"
+	"fmt"
+	"gfly/app/connections"
+	"gfly/app/constant"
+	"gfly/app/errors"
+	"github.com/gflydev/cache"
+	"github.com/gflydev/core"
+	"github.com/gflydev/core/log"
+	"github.com/gflydev/core/utils"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/redis/go-redis/v9"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ====================================================================
+// ============================ Data Types ============================
+// ====================================================================
+
+// Token errors
+var (
+	ErrInvalidToken = errors.Unauthorized("Invalid or expired token").WithCode(constant.ErrCodeInvalidToken)
+	ErrRevokedToken = errors.Unauthorized("Token was revoked").WithCode(constant.ErrCodeInvalidToken)
+)
+
+// Cache keys
+const (
+	refreshTokenKey = "auth:refresh:%s"
+	revokedTokenKey = "auth:revoked:%s"
+)
+
+// Tokens struct to describe an issued access & refresh token pair.
+type Tokens struct {
+	Access    string
+	Refresh   string
+	ExpiresAt time.Time
+}
+
+// ====================================================================
+// =========================== Access Token ===========================
+// ====================================================================
+
+// ErrMissingJWTSecret JWT_SECRET_KEY is not set. Tokens are never signed nor verified with an empty key.
+var ErrMissingJWTSecret = stdErrors.New("JWT_SECRET_KEY is not set")
+
+// ErrWeakSecret a secret is shorter than minSecretLength, like placeholder values. Anybody guessing it
+// could forge tokens or signed URLs.
+var ErrWeakSecret = stdErrors.New("secret is too short")
+
+// minSecretLength minimum length in bytes of secrets signing tokens and URLs.
+const minSecretLength = 32
+
+// jwtSecret secret key to sign access tokens.
+func jwtSecret() ([]byte, error) {
+	return secretOf("JWT_SECRET_KEY", ErrMissingJWTSecret)
+}
+
+// secretOf get secret of env key. An empty secret gets error `missing`, a short one ErrWeakSecret.
+func secretOf(key string, missing error) ([]byte, error) {
+	secret := utils.Getenv(key, "")
+	if secret == "" {
+		return nil, missing
+	}
+	if len(secret) < minSecretLength {
+		return nil, fmt.Errorf("%s: %w, it needs at least %d bytes (Ex: openssl rand -hex 32)", key, ErrWeakSecret, minSecretLength)
+	}
+
+	return []byte(secret), nil
+}
+
+// CheckSecrets verify secrets of tokens and signed URLs are set and long enough. The application must not start
+// without them.
+func CheckSecrets() error {
+	if _, err := jwtSecret(); err != nil {
+		return err
+	}
+	_, err := appKey()
+
+	return err
+}
+
+// accessTokenTTL lifetime of an access token.
+func accessTokenTTL() time.Duration {
+	return time.Duration(utils.Getenv("JWT_TTL", 15)) * time.Minute
+}
+
+// refreshTokenTTL lifetime of a refresh token.
+func refreshTokenTTL() time.Duration {
+	return time.Duration(utils.Getenv("JWT_REFRESH_TTL", 720)) * time.Hour
+}
+
+// CreateAccessToken create a signed short-lived JWT for given user ID.
+func CreateAccessToken(userID int) (string, time.Time, error) {
+	now := time.Now()
+	expiresAt := now.Add(accessTokenTTL())
+
+	claims := jwt.RegisteredClaims{
+		ID:        randomToken(16),
+		Issuer:    utils.Getenv("APP_CODE", "gfly"),
+		Subject:   strconv.Itoa(userID),
+		IssuedAt:  jwt.NewNumericDate(now),
+		ExpiresAt: jwt.NewNumericDate(expiresAt),
+	}
+
+	secret, err := jwtSecret()
+	if err != nil {
+		return "", expiresAt, errors.Internal(err)
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(secret)
+	if err != nil {
+		return "", expiresAt, err
+	}
+
+	return token, expiresAt, nil
+}
+
+// ParseAccessToken verify signature, expiration and revocation of given access token.
+func ParseAccessToken(tokenStr string) (*jwt.RegisteredClaims, error) {
+	claims := &jwt.RegisteredClaims{}
+
+	secret, err := jwtSecret()
+	if err != nil {
+		log.Error(err)
+
+		return nil, ErrInvalidToken
+	}
+
+	token, err := jwt.ParseWithClaims(tokenStr, claims, func(token *jwt.Token) (any, error) {
+		return secret, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))
+	if err != nil || !token.Valid {
+		return nil, ErrInvalidToken
+	}
+
+	if IsRevokedToken(claims.ID) {
+		return nil, ErrRevokedToken
+	}
+
+	return claims, nil
+}
+
+// RevokeAccessToken put access token into revocation list until it expires.
+func RevokeAccessToken(claims *jwt.RegisteredClaims) error {
+	ttl := time.Until(claims.ExpiresAt.Time)
+	if ttl <= 0 {
+		return nil
+	}
+
+	return cache.Set(fmt.Sprintf(revokedTokenKey, claims.ID), claims.Subject, ttl)
+}
+
+// IsRevokedToken check token ID in revocation list.
+func IsRevokedToken(tokenID string) bool {
+	val, err := cache.Get(fmt.Sprintf(revokedTokenKey, tokenID))
+
+	return err == nil && val != nil
+}
+
+// ExtractToken get bearer token from `Authorization` header.
+func ExtractToken(c *core.Ctx) string {
+	header := string(c.Root().Request.Header.Peek("Authorization"))
+
+	// Header format `Bearer <token>`
+	if scheme, token, ok := strings.Cut(header, " "); ok && strings.EqualFold(scheme, "Bearer") {
+		return strings.TrimSpace(token)
+	}
+
+	return ""
+}
+
+// ====================================================================
+// =========================== Refresh Token ==========================
+// ====================================================================
+
+// CreateRefreshToken create an opaque refresh token stored in Redis for given user ID.
+func CreateRefreshToken(userID int) (string, error) {
+	token := randomToken(32)
+
+	if err := cache.Set(fmt.Sprintf(refreshTokenKey, hashToken(token)), userID, refreshTokenTTL()); err != nil {
+		return "", err
+	}
+
+	return token, nil
+}
+
+// ConsumeRefreshToken get user ID of given refresh token then remove it. A refresh token can be used only once:
+// it is read and deleted by one GETDEL command, so concurrent requests with the same token can't both succeed.
+func ConsumeRefreshToken(token string) (int, error) {
+	if token == "" {
+		return 0, ErrInvalidToken
+	}
+
+	key := cache.Key(fmt.Sprintf(refreshTokenKey, hashToken(token)))
+	val, err := connections.CacheRedis().GetDel(context.Background(), key).Result()
+	if stdErrors.Is(err, redis.Nil) {
+		return 0, ErrInvalidToken
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	return parseTokenOwner(val)
+}
+
+// DeleteRefreshToken remove given refresh token.
+func DeleteRefreshToken(token string) error {
+	return cache.Del(fmt.Sprintf(refreshTokenKey, hashToken(token)))
+}
+
+// refreshTokenOwner get user ID of given refresh token.
+func refreshTokenOwner(token string) (int, error) {
+	if token == "" {
+		return 0, ErrInvalidToken
+	}
+
+	val, err := cache.Get(fmt.Sprintf(refreshTokenKey, hashToken(token)))
+	if err != nil || val == nil {
+		return 0, ErrInvalidToken
+	}
+
+	return parseTokenOwner(val)
+}
+
+// parseTokenOwner get user ID stored with a refresh token.
+func parseTokenOwner(val any) (int, error) {
+	userID, err := strconv.Atoi(fmt.Sprint(val))
+	if err != nil {
+		log.Errorf("Invalid refresh token owner `%v`", val)
+
+		return 0, ErrInvalidToken
+	}
+
+	return userID, nil
+}
+
+// ====================================================================
+// ============================== Helpers =============================
+// ====================================================================
+
+// randomToken generate a random hex string from n bytes.
+func randomToken(n int) string {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		log.Panic(err)
+	}
+
+	return hex.EncodeToString(b)
+}
+
+// hashToken hash a token before using it as a storage key.
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,180 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"gfly/app/domain/models"
+	"gfly/app/domain/repository"
+	"gfly/app/dto"
+	"gfly/app/errors"
+	"golang.org/x/crypto/bcrypt"
+	"time"
+)
+
+// ====================================================================
+// ============================ Data Types ============================
+// ====================================================================
+
+// User errors
+var (
+	ErrUserNotFound = errors.NotFound("User not found")
+	ErrEmailExists  = errors.Conflict("Email already exists")
+)
+
+// ====================================================================
+// ======================== User Processing ===========================
+// ====================================================================
+
+// CreateUser create a new user on behalf of an administrator. Giving roles needs permission `roles.assign`
+// and creating a blocked user needs `users.block`, on top of `users.create` checked by the route.
+func CreateUser(ctx context.Context, createUser *dto.CreateUser) (*models.User, error) {
+	var required []string
+	if len(createUser.Roles) > 0 {
+		required = append(required, models.PermissionRolesAssign)
+	}
+	if createUser.Status == models.UserStatusBlocked {
+		required = append(required, models.PermissionUsersBlock)
+	}
+
+	if err := Authorize(createUser.ActorID, required...); err != nil {
+		return nil, err
+	}
+
+	return insertUser(ctx, createUser)
+}
+
+// insertUser create a new user with hashed password and given roles. Pending user gets a verification email.
+func insertUser(ctx context.Context, createUser *dto.CreateUser) (*models.User, error) {
+	// Email of deleted users is still reserved
+	if repository.Pool.GetUserByEmail(createUser.Email, repository.WithTrashed) != nil {
+		return nil, ErrEmailExists
+	}
+
+	hashedPassword, err := HashPassword(createUser.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	status := createUser.Status
+	if status == "" {
+		status = models.UserStatusPending
+	}
+
+	now := time.Now()
+	user := &models.User{
+		Email:     createUser.Email,
+		Password:  hashedPassword,
+		Fullname:  createUser.Fullname,
+		Phone:     createUser.Phone,
+		Avatar:    sql.NullString{String: createUser.Avatar, Valid: createUser.Avatar != ""},
+		Status:    status,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if status == models.UserStatusBlocked {
+		user.BlockedAt = sql.NullTime{Time: now, Valid: true}
+	}
+
+	if err = repository.Pool.CreateUser(user, createUser.Roles...); err != nil {
+		// A concurrent request took the email after the check above
+		if repository.IsUniqueViolation(err) {
+			return nil, ErrEmailExists
+		}
+
+		return nil, err
+	}
+
+	// Pending user is activated by verifying email
+	if user.Status == models.UserStatusPending {
+		SendVerificationEmail(ctx, user, createUser.RequestID)
+	}
+
+	return user, nil
+}
+
+// UpdateUser update profile, status and password of an existing user. Changing status needs permission
+// `users.block` and setting password of another user needs `users.password`, on top of `users.update`
+// checked by the route.
+func UpdateUser(updateUser *dto.UpdateUser) (*models.User, error) {
+	user := repository.Pool.GetUserByID(updateUser.ID)
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+
+	var required []string
+	if updateUser.Status != "" && updateUser.Status != user.Status {
+		required = append(required, models.PermissionUsersBlock)
+	}
+	// Setting another password takes over the account, it is not part of moderation
+	if updateUser.Password != "" && updateUser.ActorID != user.ID {
+		required = append(required, models.PermissionUsersPassword)
+	}
+
+	if err := Authorize(updateUser.ActorID, required...); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+
+	if updateUser.Fullname != "" {
+		user.Fullname = updateUser.Fullname
+	}
+	if updateUser.Phone != "" {
+		user.Phone = updateUser.Phone
+	}
+	if updateUser.Avatar != "" {
+		user.Avatar = sql.NullString{String: updateUser.Avatar, Valid: true}
+	}
+	if updateUser.Status != "" && updateUser.Status != user.Status {
+		user.Status = updateUser.Status
+		user.BlockedAt = sql.NullTime{Time: now, Valid: updateUser.Status == models.UserStatusBlocked}
+	}
+	if updateUser.Password != "" {
+		hashedPassword, err := HashPassword(updateUser.Password)
+		if err != nil {
+			return nil, err
+		}
+		user.Password = hashedPassword
+	}
+	user.UpdatedAt = now
+
+	if err := repository.Pool.UpdateUser(user); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// DeleteUser soft delete an existing user.
+func DeleteUser(userID int) error {
+	user := repository.Pool.GetUserByID(userID)
+	if user == nil {
+		return ErrUserNotFound
+	}
+
+	return repository.Pool.DeleteUser(user)
+}
+
+// RestoreUser restore a soft deleted user.
+func RestoreUser(userID int) (*models.User, error) {
+	user := repository.Pool.GetUserByID(userID, repository.OnlyTrashed)
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+
+	if err := repository.Pool.RestoreUser(user); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// HashPassword hash a plain password by bcrypt.
+func HashPassword(password string) (string, error) {
+	hashed, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+
+	return string(hashed), nil
+}
@@ -0,0 +1,138 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"gfly/app/console/queues"
+	"gfly/app/constant"
+	"gfly/app/domain/models"
+	"gfly/app/domain/repository"
+	"gfly/app/dto"
+	"gfly/app/errors"
+	"gfly/app/tracing"
+	"github.com/gflydev/cache"
+	"github.com/gflydev/core/log"
+	"github.com/gflydev/core/utils"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ====================================================================
+// ============================ Data Types ============================
+// ====================================================================
+
+// Verification errors
+var (
+	ErrInvalidVerification = errors.BadRequest("Invalid or expired verification link").WithCode(constant.ErrCodeInvalidToken)
+	ErrEmailNotVerified    = errors.Forbidden("Email is not verified").WithCode(constant.ErrCodeEmailNotVerified)
+)
+
+// emailVerifyThrottleKey cache key to throttle verification emails of an email.
+const emailVerifyThrottleKey = "auth:verify:%s"
+
+// ====================================================================
+// ===================== Verification Processing ======================
+// ====================================================================
+
+// emailVerifyTTL lifetime of a verification link.
+func emailVerifyTTL() time.Duration {
+	return time.Duration(utils.Getenv("EMAIL_VERIFY_TTL", 1440)) * time.Minute
+}
+
+// emailVerifyThrottle minimum interval between two verification emails of an email.
+func emailVerifyThrottle() time.Duration {
+	return time.Duration(utils.Getenv("EMAIL_VERIFY_THROTTLE", 60)) * time.Second
+}
+
+// emailVerifyPath path of email verification API.
+func emailVerifyPath() string {
+	return fmt.Sprintf(
+		"/%s/%s/auth/verify-email",
+		utils.Getenv("API_PREFIX", "api"),
+		utils.Getenv("API_VERSION", "v1"),
+	)
+}
+
+// SendVerificationEmail email a signed verification link to given user.
+// The link carries a hash of current email, so it is invalid after the email changes.
+// Given request ID is passed to the queue task for log correlation.
+func SendVerificationEmail(ctx context.Context, user *models.User, requestID string) {
+	expiresAt := time.Now().Add(emailVerifyTTL())
+
+	link, err := SignURL(emailVerifyPath(), url.Values{
+		"id":   {strconv.Itoa(user.ID)},
+		"hash": {hashToken(user.Email)},
+	}, expiresAt)
+	if err != nil {
+		log.Error(err)
+
+		return
+	}
+
+	// Send email out of request processing
+	payload, name := queues.NewVerifyEmailTask(requestID, user.Email, user.Fullname, link, expiresAt)
+	tracing.DispatchTask(ctx, payload, name)
+}
+
+// ResendVerificationEmail email a new verification link if given email belongs to an unverified user.
+// The result does not tell whether the email exists.
+func ResendVerificationEmail(ctx context.Context, resend *dto.ResendVerification) error {
+	email := strings.ToLower(strings.TrimSpace(resend.Email))
+
+	// Throttle by email, no matter the email exists or not
+	throttleKey := fmt.Sprintf(emailVerifyThrottleKey, hashToken(email))
+	if val, err := cache.Get(throttleKey); err == nil && val != nil {
+		return ErrTooManyRequests
+	}
+	if err := cache.Set(throttleKey, 1, emailVerifyThrottle()); err != nil {
+		log.Error(err)
+	}
+
+	user := repository.Pool.GetUserByEmail(email)
+	if user == nil || user.VerifiedAt.Valid || user.Status == models.UserStatusBlocked {
+		return nil
+	}
+
+	SendVerificationEmail(ctx, user, resend.RequestID)
+
+	return nil
+}
+
+// VerifyEmail check a signed verification link then mark the user verified. Pending user becomes active.
+func VerifyEmail(verifyEmail *dto.VerifyEmail) (*models.User, error) {
+	params := url.Values{
+		"id":            {strconv.Itoa(verifyEmail.ID)},
+		"hash":          {verifyEmail.Hash},
+		signedExpires:   {strconv.FormatInt(verifyEmail.Expires, 10)},
+		signedSignature: {verifyEmail.Signature},
+	}
+	if err := VerifySignature(emailVerifyPath(), params); err != nil {
+		return nil, ErrInvalidVerification
+	}
+
+	user := repository.Pool.GetUserByID(verifyEmail.ID)
+	if user == nil || hashToken(user.Email) != verifyEmail.Hash {
+		return nil, ErrInvalidVerification
+	}
+
+	// Link was already used
+	if user.VerifiedAt.Valid {
+		return user, nil
+	}
+
+	now := time.Now()
+	user.VerifiedAt = sql.NullTime{Time: now, Valid: true}
+	if user.Status == models.UserStatusPending {
+		user.Status = models.UserStatusActive
+	}
+	user.UpdatedAt = now
+
+	if err := repository.Pool.UpdateUser(user); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
@@ -0,0 +1,54 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"github.com/gflydev/core/utils"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	sdkTrace "go.opentelemetry.io/otel/sdk/trace"
+	"os"
+	"path/filepath"
+)
+
+// ====================================================================
+// ============================= Exporters ============================
+// ====================================================================
+
+// Exporters of env TRACING_EXPORTER.
+const (
+	ExporterOff    = "off"
+	ExporterOTLP   = "otlp"
+	ExporterStdout = "stdout"
+	ExporterFile   = "file"
+)
+
+// newExporter create the span exporter of given kind.
+//   - `otlp` posts spans over OTLP/HTTP, configured by env OTEL_EXPORTER_OTLP_ENDPOINT, OTEL_EXPORTER_OTLP_HEADERS...
+//   - `stdout` and `file` write spans as JSON, one span per line
+func newExporter(ctx context.Context, kind string) (sdkTrace.SpanExporter, error) {
+	switch kind {
+	case ExporterOTLP:
+		return otlptracehttp.New(ctx)
+	case ExporterStdout:
+		return stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+	case ExporterFile:
+		file, err := openTraceFile(utils.Getenv("TRACING_FILE", "storage/logs/traces.log"))
+		if err != nil {
+			return nil, fmt.Errorf("could not open trace file: %w", err)
+		}
+
+		return stdouttrace.New(stdouttrace.WithWriter(file))
+	}
+
+	return nil, fmt.Errorf("unknown exporter %q", kind)
+}
+
+// openTraceFile open a trace file for appending, creating its directory.
+func openTraceFile(path string) (*os.File, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, err
+	}
+
+	return os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+}
@@ -0,0 +1,198 @@
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"gfly/app/connections"
+	"github.com/gflydev/console"
+	"github.com/gflydev/core/log"
+	"github.com/hibiken/asynq"
+	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"strings"
+	"time"
+)
+
+// ====================================================================
+// =============================== Redis ==============================
+// ====================================================================
+
+// Redis trace commands of given Redis client in client spans, children of the span of the command context.
+// The cache of `gflydev/cache` does not take a context, so its commands start their own traces.
+// Call it at startup, before the client is used.
+//
+//	tracing.Redis(connections.CacheRedis())
+func Redis(client *redis.Client) {
+	client.AddHook(redisHook{})
+}
+
+// redisHook a Redis hook tracing commands.
+type redisHook struct{}
+
+// DialHook implement redis.Hook.
+func (redisHook) DialHook(next redis.DialHook) redis.DialHook {
+	return next
+}
+
+// ProcessHook implement redis.Hook.
+func (redisHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		if !Enabled() {
+			return next(ctx, cmd)
+		}
+
+		operation := strings.ToUpper(cmd.Name())
+		ctx, span := StartSpan(ctx, "cache "+operation, trace.SpanKindClient,
+			attribute.String("db.system", "redis"),
+			attribute.String("db.operation", operation),
+		)
+		if args := cmd.Args(); len(args) > 1 {
+			if key, ok := args[1].(string); ok {
+				span.SetAttributes(attribute.String("cache.key", key))
+			}
+		}
+
+		err := next(ctx, cmd)
+		if errors.Is(err, redis.Nil) {
+			span.SetAttributes(attribute.Bool("cache.hit", false))
+		} else {
+			if operation == "GET" {
+				span.SetAttributes(attribute.Bool("cache.hit", err == nil))
+			}
+			RecordError(span, err)
+		}
+		span.End()
+
+		return err
+	}
+}
+
+// ProcessPipelineHook implement redis.Hook.
+func (redisHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		if !Enabled() {
+			return next(ctx, cmds)
+		}
+
+		ctx, span := StartSpan(ctx, "cache PIPELINE", trace.SpanKindClient,
+			attribute.String("db.system", "redis"),
+			attribute.String("db.operation", "PIPELINE"),
+			attribute.Int("db.redis.commands", len(cmds)),
+		)
+
+		err := next(ctx, cmds)
+		if !errors.Is(err, redis.Nil) {
+			RecordError(span, err)
+		}
+		span.End()
+
+		return err
+	}
+}
+
+// ====================================================================
+// =============================== Queue ==============================
+// ====================================================================
+
+// traceParentField payload field carrying the trace context to the worker, see `queues.TaskMeta`.
+const traceParentField = "TraceParent"
+
+// DispatchTask push a task to queue like `console.DispatchTask` within a producer span, child of the span
+// of `ctx`. The producer span is written in payload field `TraceParent`, so the worker continues the trace.
+// Tasks are pushed by the shared client `connections.Queue`, closed on shutdown.
+//
+//	payload, name := queues.NewHelloTask("Hello")
+//	tracing.DispatchTask(ctx, payload, name)
+func DispatchTask(ctx context.Context, payload any, name string) {
+	startTime := time.Now()
+
+	ctx, span := StartSpan(ctx, "enqueue "+name, trace.SpanKindProducer,
+		attribute.String("messaging.system", "asynq"),
+		attribute.String("messaging.destination.name", name),
+	)
+	defer span.End()
+
+	data, err := json.Marshal(withTraceParent(ctx, payload))
+	if err != nil {
+		log.Errorf("Encode error %v. Error %v", payload, err)
+		RecordError(span, err)
+
+		return
+	}
+
+	info, err := connections.Queue().EnqueueContext(ctx, asynq.NewTask(name, data))
+	if err != nil {
+		log.Errorf("Could not enqueue task: %v", err)
+		RecordError(span, err)
+
+		return
+	}
+
+	span.SetAttributes(attribute.String("messaging.message.id", info.ID))
+	log.Infof("[RUN] Dispatch Task %s - %v", name, time.Since(startTime))
+}
+
+// withTraceParent get given payload with field `TraceParent` set to the span of `ctx`.
+// Payloads which are not JSON objects are returned unchanged.
+func withTraceParent(ctx context.Context, payload any) any {
+	traceParent := Inject(ctx)
+	if traceParent == "" {
+		return payload
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return payload
+	}
+
+	var fields map[string]json.RawMessage
+	if err = json.Unmarshal(data, &fields); err != nil || fields == nil {
+		return payload
+	}
+
+	fields[traceParentField], _ = json.Marshal(traceParent)
+
+	return fields
+}
+
+// Task wrap a queue task to continue the trace of its payload field `TraceParent` in a consumer span.
+// The context given to the task holds the span, pass it on to trace the work of the task.
+//
+//	console.RegisterTask(tracing.Task("hello-world", &HelloTask{}), "hello-world")
+func Task(name string, task console.ITask) console.ITask {
+	return &queueTask{name: name, ITask: task}
+}
+
+// queueTask a queue task tracing its runs.
+type queueTask struct {
+	console.ITask
+	name string
+}
+
+// Dequeue implement console.ITask.
+func (t *queueTask) Dequeue(ctx context.Context, task *asynq.Task) error {
+	if !Enabled() {
+		return t.ITask.Dequeue(ctx, task)
+	}
+
+	var meta struct {
+		TraceParent string
+	}
+	_ = json.Unmarshal(task.Payload(), &meta)
+
+	ctx, span := StartSpan(Extract(ctx, meta.TraceParent), "process "+t.name, trace.SpanKindConsumer,
+		attribute.String("messaging.system", "asynq"),
+		attribute.String("messaging.destination.name", t.name),
+	)
+	if id, ok := asynq.GetTaskID(ctx); ok {
+		span.SetAttributes(attribute.String("messaging.message.id", id))
+	}
+
+	err := t.ITask.Dequeue(ctx, task)
+	RecordError(span, err)
+	span.End()
+
+	return err
+}
@@ -0,0 +1,28 @@
+package tracing
+
+import (
+	"context"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// ====================================================================
+// ============================ Propagation ===========================
+// ====================================================================
+
+// HeaderTraceParent W3C trace context header.
+const HeaderTraceParent = "traceparent"
+
+// Inject format the span of `ctx` as a W3C `traceparent` value, like
+// `00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01`. Return empty when `ctx` holds no span.
+func Inject(ctx context.Context) string {
+	carrier := propagation.MapCarrier{}
+	propagator.Inject(ctx, carrier)
+
+	return carrier.Get(HeaderTraceParent)
+}
+
+// Extract get a context continuing the trace of given `traceparent` value. Spans started from the
+// context are children of the remote span. Invalid values are ignored.
+func Extract(ctx context.Context, traceParent string) context.Context {
+	return propagator.Extract(ctx, propagation.MapCarrier{HeaderTraceParent: traceParent})
+}
@@ -0,0 +1,67 @@
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"go.opentelemetry.io/otel/trace"
+	"testing"
+)
+
+const testTraceParent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+func TestInjectExtract(t *testing.T) {
+	tests := []struct {
+		name        string
+		traceParent string
+		want        string
+	}{
+		{"valid", testTraceParent, testTraceParent},
+		{"not sampled", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00"},
+		{"empty", "", ""},
+		{"zero trace ID", "00-00000000000000000000000000000000-00f067aa0ba902b7-01", ""},
+		{"malformed", "00-4bf92f35-00f067aa0ba902b7-01", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Inject(Extract(context.Background(), tt.traceParent)); got != tt.want {
+				t.Fatalf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestWithTraceParent(t *testing.T) {
+	type payload struct {
+		RequestID   string
+		TraceParent string
+		Message     string
+	}
+
+	ctx := Extract(context.Background(), testTraceParent)
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		t.Fatal("expected a remote span in context")
+	}
+
+	data, err := json.Marshal(withTraceParent(ctx, payload{RequestID: "req-1", Message: "Hello"}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var got payload
+	if err = json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got.TraceParent != testTraceParent || got.RequestID != "req-1" || got.Message != "Hello" {
+		t.Fatalf("unexpected payload %+v", got)
+	}
+
+	// Without a span or a JSON object, payloads are unchanged
+	if p := withTraceParent(context.Background(), payload{Message: "Hello"}); p != (payload{Message: "Hello"}) {
+		t.Fatalf("expected unchanged payload, got %v", p)
+	}
+	if p := withTraceParent(ctx, "Hello"); p != "Hello" {
+		t.Fatalf("expected unchanged payload, got %v", p)
+	}
+}
@@ -0,0 +1,229 @@
+package tracing
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"github.com/gflydev/core/utils"
+	mb "github.com/gflydev/db"
+	dbPSQL "github.com/gflydev/db/psql"
+	"github.com/jmoiron/sqlx"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"strings"
+	"sync"
+)
+
+// ====================================================================
+// ============================= Database =============================
+// ====================================================================
+
+// maxStatementLength longest SQL statement recorded in spans.
+const maxStatementLength = 2048
+
+// Postgres a PostgreSQL driver like `dbPSQL.New()` sending queries through a traced SQL driver
+// while tracing is enabled.
+//
+//	mb.Register(repository.Driver(tracing.Postgres()))
+//	mb.Load()
+func Postgres() mb.IDatabase {
+	return &postgres{dbPSQL.New()}
+}
+
+// postgres a traced PostgreSQL driver.
+type postgres struct {
+	*dbPSQL.PostgreSQL
+}
+
+// Load implement mb.IDatabase.
+func (d *postgres) Load() (*sqlx.DB, error) {
+	if !Enabled() {
+		return d.PostgreSQL.Load()
+	}
+
+	connURL := fmt.Sprintf(
+		"postgres://%s:%s@%s:%v/%s?sslmode=%s",
+		utils.Getenv("DB_USERNAME", "user"),
+		utils.Getenv("DB_PASSWORD", "secret"),
+		utils.Getenv("DB_HOST", "localhost"),
+		utils.Getenv("DB_PORT", 5432),
+		utils.Getenv("DB_NAME", "gfly"),
+		utils.Getenv("DB_SSL_MODE", "disable"),
+	)
+
+	driverName, err := SQLDriver("pgx")
+	if err != nil {
+		return nil, err
+	}
+
+	return mb.Connect(connURL, driverName)
+}
+
+var sqlDriversLock sync.Mutex
+
+// SQLDriver register a traced version of SQL driver `name` and get its name. Queries and executions
+// issued through it get client spans, children of the span of their context. The model builder does not
+// take a context, so its queries start their own traces.
+func SQLDriver(name string) (string, error) {
+	sqlDriversLock.Lock()
+	defer sqlDriversLock.Unlock()
+
+	tracedName := name + "-traced"
+	for _, registered := range sql.Drivers() {
+		if registered == tracedName {
+			return tracedName, nil
+		}
+	}
+
+	// Opening does not connect, it only looks the driver up.
+	db, err := sql.Open(name, "")
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+
+	system := name
+	if name == "pgx" || name == "postgres" {
+		system = "postgresql"
+	}
+
+	sql.Register(tracedName, &sqlDriver{Driver: db.Driver(), system: system})
+
+	return tracedName, nil
+}
+
+// ====================================================================
+// ============================ SQL Driver ============================
+// ====================================================================
+
+// sqlDriver a SQL driver tracing its connections.
+type sqlDriver struct {
+	driver.Driver
+	system string // Database system of spans, like `postgresql`
+}
+
+// Open implement driver.Driver.
+func (d *sqlDriver) Open(name string) (driver.Conn, error) {
+	conn, err := d.Driver.Open(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sqlConn{Conn: conn, system: d.system}, nil
+}
+
+// sqlConn a SQL connection tracing queries and executions.
+type sqlConn struct {
+	driver.Conn
+	system string
+}
+
+// QueryContext implement driver.QueryerContext.
+func (c *sqlConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	queryer, ok := c.Conn.(driver.QueryerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+
+	span := startSQLSpan(ctx, c.system, query)
+	rows, err := queryer.QueryContext(ctx, query, args)
+	finishSQLSpan(span, err)
+
+	return rows, err
+}
+
+// ExecContext implement driver.ExecerContext.
+func (c *sqlConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	execer, ok := c.Conn.(driver.ExecerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+
+	span := startSQLSpan(ctx, c.system, query)
+	result, err := execer.ExecContext(ctx, query, args)
+	finishSQLSpan(span, err)
+
+	return result, err
+}
+
+// PrepareContext implement driver.ConnPrepareContext.
+func (c *sqlConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	if preparer, ok := c.Conn.(driver.ConnPrepareContext); ok {
+		return preparer.PrepareContext(ctx, query)
+	}
+
+	return c.Conn.Prepare(query)
+}
+
+// BeginTx implement driver.ConnBeginTx.
+func (c *sqlConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	if beginner, ok := c.Conn.(driver.ConnBeginTx); ok {
+		return beginner.BeginTx(ctx, opts)
+	}
+
+	return c.Conn.Begin() //nolint:staticcheck // Fallback of drivers without ConnBeginTx
+}
+
+// Ping implement driver.Pinger.
+func (c *sqlConn) Ping(ctx context.Context) error {
+	if pinger, ok := c.Conn.(driver.Pinger); ok {
+		return pinger.Ping(ctx)
+	}
+
+	return nil
+}
+
+// ResetSession implement driver.SessionResetter.
+func (c *sqlConn) ResetSession(ctx context.Context) error {
+	if resetter, ok := c.Conn.(driver.SessionResetter); ok {
+		return resetter.ResetSession(ctx)
+	}
+
+	return nil
+}
+
+// IsValid implement driver.Validator.
+func (c *sqlConn) IsValid() bool {
+	if validator, ok := c.Conn.(driver.Validator); ok {
+		return validator.IsValid()
+	}
+
+	return true
+}
+
+// CheckNamedValue implement driver.NamedValueChecker, keeping argument conversions of the driver.
+func (c *sqlConn) CheckNamedValue(value *driver.NamedValue) error {
+	if checker, ok := c.Conn.(driver.NamedValueChecker); ok {
+		return checker.CheckNamedValue(value)
+	}
+
+	return driver.ErrSkip
+}
+
+// startSQLSpan start a client span of a SQL statement, named by its operation like `SELECT`.
+func startSQLSpan(ctx context.Context, system, query string) trace.Span {
+	operation, _, _ := strings.Cut(strings.TrimSpace(query), " ")
+	operation = strings.ToUpper(operation)
+
+	_, span := StartSpan(ctx, "db "+operation, trace.SpanKindClient,
+		attribute.String("db.system", system),
+		attribute.String("db.operation", operation),
+		attribute.String("db.statement", query[:min(len(query), maxStatementLength)]),
+	)
+
+	return span
+}
+
+// finishSQLSpan end a SQL span. Skipped calls are dropped, database/sql retries them another way.
+func finishSQLSpan(span trace.Span, err error) {
+	if errors.Is(err, driver.ErrSkip) {
+		return
+	}
+
+	RecordError(span, err)
+	span.End()
+}
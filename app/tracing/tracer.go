@@ -0,0 +1,113 @@
+package tracing
+
+import (
+	"context"
+	"github.com/gflydev/core/log"
+	"github.com/gflydev/core/utils"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdkTrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"strconv"
+)
+
+// ====================================================================
+// ============================= Lifecycle ============================
+// ====================================================================
+
+// instrumentationName name of the tracer of the application.
+const instrumentationName = "gfly/app/tracing"
+
+// provider tracer provider set up by Start, nil while tracing is disabled.
+var provider *sdkTrace.TracerProvider
+
+// Start set up the OpenTelemetry tracer provider from env TRACING_EXPORTER: `off` (default), `otlp` to
+// send spans to OTEL_EXPORTER_OTLP_ENDPOINT, `stdout` or `file` (TRACING_FILE) for offline development.
+// Call it once at startup, after loading `.env`.
+//
+//	tracing.Start()
+//	defer tracing.Shutdown(context.Background())
+func Start() {
+	kind := utils.Getenv("TRACING_EXPORTER", ExporterOff)
+	if kind == ExporterOff || kind == "" {
+		return
+	}
+
+	exporter, err := newExporter(context.Background(), kind)
+	if err != nil {
+		log.Errorf("Tracing is disabled: %v", err)
+
+		return
+	}
+
+	ratio, err := strconv.ParseFloat(utils.Getenv("TRACING_SAMPLE_RATIO", "1"), 64)
+	if err != nil {
+		ratio = 1
+	}
+
+	serviceName := attribute.String("service.name", utils.Getenv("OTEL_SERVICE_NAME", utils.Getenv("APP_CODE", "gfly")))
+	serviceResource, err := resource.Merge(resource.Default(), resource.NewSchemaless(serviceName))
+	if err != nil {
+		serviceResource = resource.Default()
+	}
+
+	// Sampling is decided at the root of a trace, other services and workers follow it
+	provider = sdkTrace.NewTracerProvider(
+		sdkTrace.WithBatcher(exporter),
+		sdkTrace.WithSampler(sdkTrace.ParentBased(sdkTrace.TraceIDRatioBased(ratio))),
+		sdkTrace.WithResource(serviceResource),
+	)
+
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagator)
+}
+
+// Enabled check tracing was started with an exporter.
+func Enabled() bool {
+	return provider != nil
+}
+
+// Shutdown export pending spans, waiting until `ctx` is done at most.
+func Shutdown(ctx context.Context) {
+	if provider == nil {
+		return
+	}
+
+	if err := provider.Shutdown(ctx); err != nil {
+		log.Errorf("Tracing shutdown failed: %v", err)
+	}
+}
+
+// ====================================================================
+// =============================== Spans ==============================
+// ====================================================================
+
+// Tracer get the tracer of the application. Its spans are not recorded while tracing is disabled.
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// StartSpan start a span as child of the span in `ctx`. Return the context holding the new span.
+// Always End the span.
+//
+//	ctx, span := tracing.StartSpan(ctx, "send mail", trace.SpanKindClient)
+//	defer span.End()
+func StartSpan(ctx context.Context, name string, kind trace.SpanKind, attributes ...attribute.KeyValue) (context.Context, trace.Span) {
+	return Tracer().Start(ctx, name, trace.WithSpanKind(kind), trace.WithAttributes(attributes...))
+}
+
+// RecordError mark given span as failed by given error. A nil error is ignored.
+func RecordError(span trace.Span, err error) {
+	if err == nil {
+		return
+	}
+
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
+
+// propagator W3C trace context propagator of headers and task payloads.
+var propagator = propagation.TraceContext{}
@@ -0,0 +1,55 @@
+package utils
+
+import (
+	"errors"
+	"net/mail"
+	"regexp"
+	"unicode"
+)
+
+// phonePattern an optional leading `+` followed by 8 to 15 digits. Spaces, dots and dashes are allowed as separators.
+var phonePattern = regexp.MustCompile(`^\+?[0-9](?:[ .-]?[0-9]){7,14}$`)
+
+// MinPasswordLength minimum length of a password.
+const MinPasswordLength = 8
+
+// IsEmail check given string is a plain email address `local@domain`.
+func IsEmail(email string) bool {
+	address, err := mail.ParseAddress(email)
+
+	return err == nil && address.Address == email
+}
+
+// IsPhone check given string is a phone number.
+//
+//	IsPhone("0989831911")      // true
+//	IsPhone("+84 989 831 911") // true
+func IsPhone(phone string) bool {
+	return phonePattern.MatchString(phone)
+}
+
+// CheckPassword verify password policy: at least MinPasswordLength characters
+// with upper case, lower case letters and digits.
+func CheckPassword(password string) error {
+	if len([]rune(password)) < MinPasswordLength {
+		return errors.New("Password must have at least 8 characters")
+	}
+
+	var hasUpper, hasLower, hasDigit bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		}
+	}
+
+	if !hasUpper || !hasLower || !hasDigit {
+		return errors.New("Password must contain upper case, lower case letters and digits")
+	}
+
+	return nil
+}
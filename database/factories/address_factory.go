@@ -0,0 +1,39 @@
+package factories
+
+import (
+	"gfly/app/domain/models"
+	"time"
+)
+
+// ---------------------------------------------------------------
+//                      Address factory.
+// ---------------------------------------------------------------
+
+// Address states.
+const (
+	StateBilling  = "billing"
+	StateShipping = "shipping"
+)
+
+// Address get factory of default addresses of user userID.
+func Address(userID int) *Factory[models.Address] {
+	return New(func(int) models.Address {
+		return models.Address{
+			UserID:       userID,
+			Type:         models.AddressTypeAddress,
+			IsDefault:    true,
+			AddressLine1: Street(),
+			Ward:         NullString(Ward()),
+			District:     NullString(District()),
+			City:         NullString(City()),
+			Country:      NullString("Vietnam"),
+			CreatedAt:    time.Now(),
+		}
+	}).
+		State(StateBilling, func(address *models.Address) {
+			address.Type = models.AddressTypeBilling
+		}).
+		State(StateShipping, func(address *models.Address) {
+			address.Type = models.AddressTypeShipping
+		})
+}
@@ -0,0 +1,192 @@
+package factories
+
+import (
+	"fmt"
+	"gfly/app/domain/repository"
+	mb "github.com/gflydev/db"
+	"maps"
+	"sync/atomic"
+)
+
+// ---------------------------------------------------------------
+//                      Factory types.
+// ---------------------------------------------------------------
+
+// Definition build default attributes of a model. Sequence is unique per factory in the process.
+type Definition[T any] func(sequence int) T
+
+// State modify attributes of a model.
+type State[T any] func(m *T)
+
+// Hook run after a model was persisted, to create its relationships within the same transaction.
+type Hook[T any] func(tx *mb.DBModel, m *T) error
+
+// Factory generic builder of models with fake data.
+//
+//	user := factories.User().With("verified").Make()
+//	users, err := factories.User().Count(10).CreateMany()
+type Factory[T any] struct {
+	definition Definition[T]
+	sequence   *atomic.Int64
+	states     map[string]State[T]
+	applied    []State[T]
+	hooks      []Hook[T]
+	count      int
+}
+
+// New create a factory of model T from definition.
+func New[T any](definition Definition[T]) *Factory[T] {
+	return &Factory[T]{
+		definition: definition,
+		sequence:   &atomic.Int64{},
+		states:     make(map[string]State[T]),
+		count:      1,
+	}
+}
+
+// clone copy factory, so a base factory can be reused with other states. Clones share the sequence.
+func (f *Factory[T]) clone() *Factory[T] {
+	c := *f
+	c.states = maps.Clone(f.states)
+	c.applied = append([]State[T]{}, f.applied...)
+	c.hooks = append([]Hook[T]{}, f.hooks...)
+
+	return &c
+}
+
+// ---------------------------------------------------------------
+//                      Factory builder.
+// ---------------------------------------------------------------
+
+// State define a named state which can be applied by With.
+func (f *Factory[T]) State(name string, state State[T]) *Factory[T] {
+	c := f.clone()
+	c.states[name] = state
+
+	return c
+}
+
+// With apply named states in order. Unknown state is a programming error and panics.
+func (f *Factory[T]) With(names ...string) *Factory[T] {
+	c := f.clone()
+	for _, name := range names {
+		state, ok := f.states[name]
+		if !ok {
+			panic(fmt.Sprintf("factory state %q is not defined", name))
+		}
+		c.applied = append(c.applied, state)
+	}
+
+	return c
+}
+
+// Tap apply an anonymous state, to override some attributes.
+func (f *Factory[T]) Tap(state State[T]) *Factory[T] {
+	c := f.clone()
+	c.applied = append(c.applied, state)
+
+	return c
+}
+
+// AfterCreate add a hook running after each model was persisted.
+func (f *Factory[T]) AfterCreate(hook Hook[T]) *Factory[T] {
+	c := f.clone()
+	c.hooks = append(c.hooks, hook)
+
+	return c
+}
+
+// Count set number of models built by MakeMany and CreateMany.
+func (f *Factory[T]) Count(count int) *Factory[T] {
+	c := f.clone()
+	c.count = count
+
+	return c
+}
+
+// ---------------------------------------------------------------
+//                      Factory output.
+// ---------------------------------------------------------------
+
+// Make build a model in memory.
+func (f *Factory[T]) Make() T {
+	m := f.definition(int(f.sequence.Add(1)))
+	for _, state := range f.applied {
+		state(&m)
+	}
+
+	return m
+}
+
+// MakeMany build Count models in memory.
+func (f *Factory[T]) MakeMany() []T {
+	items := make([]T, 0, f.count)
+	for range f.count {
+		items = append(items, f.Make())
+	}
+
+	return items
+}
+
+// Create build a model, persist it then run AfterCreate hooks, all in a new transaction.
+func (f *Factory[T]) Create() (*T, error) {
+	var m *T
+	err := repository.Transaction(func(tx *mb.DBModel) (err error) {
+		m, err = f.CreateIn(tx)
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// CreateIn build a model, persist it then run AfterCreate hooks in transaction `tx`, like the one given
+// to seeders. Nothing is kept when the transaction is rolled back.
+//
+//	user, err := factories.User().AfterCreate(factories.WithAddresses(1)).CreateIn(tx)
+func (f *Factory[T]) CreateIn(tx *mb.DBModel) (*T, error) {
+	m := f.Make()
+	if err := tx.Create(&m); err != nil {
+		return nil, err
+	}
+
+	for _, hook := range f.hooks {
+		if err := hook(tx, &m); err != nil {
+			return nil, err
+		}
+	}
+
+	return &m, nil
+}
+
+// CreateMany build and persist Count models in a new transaction.
+func (f *Factory[T]) CreateMany() ([]T, error) {
+	var items []T
+	err := repository.Transaction(func(tx *mb.DBModel) (err error) {
+		items, err = f.CreateManyIn(tx)
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+// CreateManyIn build and persist Count models in transaction `tx`.
+func (f *Factory[T]) CreateManyIn(tx *mb.DBModel) ([]T, error) {
+	items := make([]T, 0, f.count)
+	for range f.count {
+		m, err := f.CreateIn(tx)
+		if err != nil {
+			return items, err
+		}
+		items = append(items, *m)
+	}
+
+	return items, nil
+}
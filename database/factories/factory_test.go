@@ -0,0 +1,49 @@
+package factories
+
+import "testing"
+
+// item a model of test factories.
+type item struct {
+	Sequence int
+	Status   string
+}
+
+func TestStateDoesNotModifyBaseFactory(t *testing.T) {
+	base := New(func(sequence int) item {
+		return item{Sequence: sequence, Status: "draft"}
+	}).State("published", func(m *item) {
+		m.Status = "published"
+	})
+
+	archived := base.State("archived", func(m *item) {
+		m.Status = "archived"
+	})
+
+	if got := archived.With("archived").Make().Status; got != "archived" {
+		t.Fatalf("expected archived, got %s", got)
+	}
+	if got := archived.With("published").Make().Status; got != "published" {
+		t.Fatalf("expected base state published on derived factory, got %s", got)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected base factory not to know state archived")
+		}
+	}()
+	base.With("archived")
+}
+
+func TestDerivedFactoriesShareSequence(t *testing.T) {
+	base := New(func(sequence int) item {
+		return item{Sequence: sequence}
+	})
+
+	first := base.Make()
+	second := base.State("other", func(*item) {}).Make()
+	third := base.Tap(func(*item) {}).Make()
+
+	if first.Sequence != 1 || second.Sequence != 2 || third.Sequence != 3 {
+		t.Fatalf("expected sequences 1, 2, 3, got %d, %d, %d", first.Sequence, second.Sequence, third.Sequence)
+	}
+}
@@ -0,0 +1,87 @@
+package factories
+
+import (
+	"database/sql"
+	"fmt"
+	"math/rand/v2"
+	"strings"
+	"time"
+)
+
+// ---------------------------------------------------------------
+//                      Fake data generators.
+// ---------------------------------------------------------------
+
+// run unique token of process, keeps generated emails and slugs unique across runs.
+var run = fmt.Sprintf("%06x", rand.IntN(0xffffff))
+
+var (
+	firstNames = []string{"An", "Binh", "Chi", "Dung", "Giang", "Hoa", "Khanh", "Linh", "Minh", "Nam", "Phuong", "Quan", "Thao", "Tuan", "Vy"}
+	lastNames  = []string{"Nguyen", "Tran", "Le", "Pham", "Hoang", "Vu", "Dang", "Bui", "Do", "Ngo"}
+	streets    = []string{"Le Loi", "Nguyen Hue", "Hai Ba Trung", "Tran Hung Dao", "Ly Thuong Kiet", "Dien Bien Phu"}
+	wards      = []string{"Ben Nghe", "Ben Thanh", "Da Kao", "Tan Dinh", "Phuoc Vinh", "Thach Thang"}
+	districts  = []string{"District 1", "District 3", "Binh Thanh", "Hai Chau", "Hoan Kiem", "Ba Dinh"}
+	cities     = []string{"Ho Chi Minh", "Ha Noi", "Da Nang", "Hue", "Can Tho", "Hai Phong"}
+)
+
+// Pick get a random element of items.
+func Pick[T any](items []T) T {
+	return items[rand.IntN(len(items))]
+}
+
+// Name get a random full name.
+func Name() string {
+	return Pick(firstNames) + " " + Pick(lastNames)
+}
+
+// Email get a unique email of name, under domain example.test.
+func Email(name string, sequence int) string {
+	local := strings.ToLower(strings.ReplaceAll(name, " ", "."))
+
+	return fmt.Sprintf("%s.%s.%d@example.test", local, run, sequence)
+}
+
+// Slug get a unique slug of prefix.
+func Slug(prefix string, sequence int) string {
+	return fmt.Sprintf("%s-%s-%d", prefix, run, sequence)
+}
+
+// Phone get a random mobile phone number.
+func Phone() string {
+	return fmt.Sprintf("09%08d", rand.IntN(100000000))
+}
+
+// Street get a random street address line.
+func Street() string {
+	return fmt.Sprintf("%d %s", 1+rand.IntN(300), Pick(streets))
+}
+
+// Ward get a random ward.
+func Ward() string {
+	return Pick(wards)
+}
+
+// District get a random district.
+func District() string {
+	return Pick(districts)
+}
+
+// City get a random city.
+func City() string {
+	return Pick(cities)
+}
+
+// PastTime get a random time within the last given duration.
+func PastTime(within time.Duration) time.Time {
+	return time.Now().Add(-time.Duration(rand.Int64N(int64(within))))
+}
+
+// NullString get a valid sql.NullString of s.
+func NullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: true}
+}
+
+// NullTime get a valid sql.NullTime of t.
+func NullTime(t time.Time) sql.NullTime {
+	return sql.NullTime{Time: t, Valid: true}
+}
@@ -0,0 +1,41 @@
+package factories
+
+import (
+	"gfly/app/domain/models"
+	"time"
+)
+
+// ---------------------------------------------------------------
+//                      Role factory.
+// ---------------------------------------------------------------
+
+// roleFactory shared factory, so sequence keeps unique across calls of Role().
+var roleFactory = New(func(sequence int) models.Role {
+	slug := Slug("role", sequence)
+
+	return models.Role{
+		Name:      slug,
+		Slug:      slug,
+		CreatedAt: time.Now(),
+	}
+})
+
+// Role get factory of roles with unique slugs.
+func Role() *Factory[models.Role] {
+	return roleFactory
+}
+
+// ---------------------------------------------------------------
+//                      UserRole factory.
+// ---------------------------------------------------------------
+
+// UserRole get factory assigning role roleID to user userID.
+func UserRole(userID, roleID int) *Factory[models.UserRole] {
+	return New(func(int) models.UserRole {
+		return models.UserRole{
+			UserID:    userID,
+			RoleID:    roleID,
+			CreatedAt: time.Now(),
+		}
+	})
+}
@@ -0,0 +1,127 @@
+package factories
+
+import (
+	"gfly/app/domain/models"
+	mb "github.com/gflydev/db"
+	qb "github.com/jivegroup/fluentsql" // Query builder
+	"time"
+)
+
+// ---------------------------------------------------------------
+//                      User factory.
+// ---------------------------------------------------------------
+
+// Password plain password of users made by factory.
+const Password = "P@seWor9"
+
+// passwordHash bcrypt hash of Password, low cost to keep factories fast.
+const passwordHash = "$2a$04$9QD944312deeQjnxF.zNauGx7NQ0GtS.xJhLy.zWqWxOE8B/XCN9i"
+
+// User states.
+const (
+	StateActive   = "active"
+	StatePending  = "pending"
+	StateBlocked  = "blocked"
+	StateVerified = "verified"
+	StateDeleted  = "deleted"
+)
+
+// userFactory shared factory, so sequence keeps unique across calls of User().
+var userFactory = New(func(sequence int) models.User {
+	name := Name()
+	now := time.Now()
+
+	return models.User{
+		Email:     Email(name, sequence),
+		Password:  passwordHash,
+		Fullname:  name,
+		Phone:     Phone(),
+		Status:    models.UserStatusActive,
+		Avatar:    NullString("https://www.gfly.dev/assets/avatar.png"),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}).
+	State(StateActive, func(user *models.User) {
+		user.Status = models.UserStatusActive
+	}).
+	State(StatePending, func(user *models.User) {
+		user.Status = models.UserStatusPending
+		user.VerifiedAt.Valid = false
+	}).
+	State(StateBlocked, func(user *models.User) {
+		user.Status = models.UserStatusBlocked
+		user.BlockedAt = NullTime(time.Now())
+	}).
+	State(StateVerified, func(user *models.User) {
+		user.VerifiedAt = NullTime(PastTime(30 * 24 * time.Hour))
+	}).
+	State(StateDeleted, func(user *models.User) {
+		user.DeletedAt = NullTime(time.Now())
+	})
+
+// User get factory of active users with password Password.
+//
+//	user := factories.User().With(factories.StateBlocked).Make()
+//	admin, err := factories.User().AfterCreate(factories.AttachRoles("admin")).Create()
+func User() *Factory[models.User] {
+	return userFactory
+}
+
+// ---------------------------------------------------------------
+//                      User relationships.
+// ---------------------------------------------------------------
+
+// WithRoles hook creating count new roles and assigning them to user.
+//
+//	user, err := factories.User().AfterCreate(factories.WithRoles(3)).Create()
+func WithRoles(count int) Hook[models.User] {
+	return func(tx *mb.DBModel, user *models.User) error {
+		roles, err := Role().Count(count).CreateManyIn(tx)
+		if err != nil {
+			return err
+		}
+
+		for _, role := range roles {
+			if _, err = UserRole(user.ID, role.ID).CreateIn(tx); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+// AttachRoles hook assigning existing roles of given slugs to user.
+func AttachRoles(slugs ...string) Hook[models.User] {
+	return func(tx *mb.DBModel, user *models.User) error {
+		for _, slug := range slugs {
+			var role models.Role
+			if err := tx.Where("slug", qb.Eq, slug).First(&role); err != nil {
+				return err
+			}
+
+			if _, err := UserRole(user.ID, role.ID).CreateIn(tx); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+// WithAddresses hook creating count addresses of user. The first one is default.
+func WithAddresses(count int) Hook[models.User] {
+	return func(tx *mb.DBModel, user *models.User) error {
+		for i := range count {
+			_, err := Address(user.ID).Tap(func(address *models.Address) {
+				address.IsDefault = i == 0
+			}).CreateIn(tx)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,13 @@
+// Package migrations embeds SQL migration files into binaries, so deployments do not need them on disk.
+package migrations
+
+import "embed"
+
+// Dir directory of the migrations run by artisan. The migrator supports PostgreSQL only, like the database
+// driver registered by the application. Directory `mysql` is neither embedded nor generated.
+const Dir = "postgresql"
+
+// FS migration files of Dir.
+//
+//go:embed postgresql/*.sql
+var FS embed.FS
@@ -1,4 +1,5 @@
 -- Delete tables
+DROP TABLE IF EXISTS address;
 DROP TABLE IF EXISTS user_roles;
 DROP TABLE IF EXISTS roles;
 DROP TABLE IF EXISTS users;
\ No newline at end of file
@@ -48,8 +48,8 @@ INSERT INTO roles (name, slug) VALUES('Guest', 'guest');
 -- -----------------------------------------------------
 CREATE TABLE user_roles (
                             id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
-                            role_id INT,
-                            user_id BIGINT,
+                            role_id INT UNSIGNED,
+                            user_id BIGINT UNSIGNED,
                             created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
                             CONSTRAINT fk_user_to_role
                                 FOREIGN KEY (role_id)
@@ -65,8 +65,8 @@ CREATE TABLE user_roles (
 -- Table 'address'
 -- -----------------------------------------------------
 CREATE TABLE address (
-                         id SERIAL PRIMARY KEY,
-                         user_id BIGINT,
+                         id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+                         user_id BIGINT UNSIGNED,
                          type ENUM ('address', 'billing', 'shipping') NOT NULL DEFAULT 'address',
                          is_default BOOL DEFAULT TRUE,
                          address_line1 VARCHAR(150) NOT NULL,
@@ -0,0 +1,3 @@
+-- Delete tables
+DROP TABLE IF EXISTS role_permissions;
+DROP TABLE IF EXISTS permissions;
@@ -0,0 +1,67 @@
+-- -----------------------------------------------------
+-- Table permissions
+-- -----------------------------------------------------
+CREATE TABLE permissions (
+                             id INT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+                             name VARCHAR(100) NOT NULL,
+                             slug VARCHAR(100) NOT NULL UNIQUE,
+                             created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                             updated_at TIMESTAMP NULL
+);
+
+-- -----------------------------------------------------
+-- Table role_permissions
+-- -----------------------------------------------------
+CREATE TABLE role_permissions (
+                                  id INT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+                                  role_id INT UNSIGNED NOT NULL,
+                                  permission_id INT UNSIGNED NOT NULL,
+                                  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                                  CONSTRAINT fk_role_to_permission
+                                      FOREIGN KEY (role_id)
+                                          REFERENCES roles (id)
+                                          ON DELETE CASCADE,
+                                  CONSTRAINT fk_permission_to_role
+                                      FOREIGN KEY (permission_id)
+                                          REFERENCES permissions (id)
+                                          ON DELETE CASCADE,
+                                  CONSTRAINT uq_role_permission
+                                      UNIQUE (role_id, permission_id)
+);
+
+-- --------------------------------------------------------------------------------------
+-- ------------------------------------ Initial data ------------------------------------
+-- --------------------------------------------------------------------------------------
+INSERT INTO permissions (name, slug) VALUES('List users', 'users.list');
+INSERT INTO permissions (name, slug) VALUES('View user', 'users.view');
+INSERT INTO permissions (name, slug) VALUES('Create user', 'users.create');
+INSERT INTO permissions (name, slug) VALUES('Update user', 'users.update');
+INSERT INTO permissions (name, slug) VALUES('Delete user', 'users.delete');
+INSERT INTO permissions (name, slug) VALUES('Block user', 'users.block');
+INSERT INTO permissions (name, slug) VALUES('Set user password', 'users.password');
+INSERT INTO permissions (name, slug) VALUES('Assign roles', 'roles.assign');
+INSERT INTO permissions (name, slug) VALUES('View profile', 'profile.view');
+INSERT INTO permissions (name, slug) VALUES('Update profile', 'profile.update');
+INSERT INTO permissions (name, slug) VALUES('Manage system', 'system.manage');
+
+-- Admin: all permissions
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id FROM roles r CROSS JOIN permissions p WHERE r.slug = 'admin';
+
+-- Moderator: user moderation
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id FROM roles r CROSS JOIN permissions p
+WHERE r.slug = 'moderator'
+  AND p.slug IN ('users.list', 'users.view', 'users.update', 'users.block', 'profile.view', 'profile.update');
+
+-- Member: own profile
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id FROM roles r CROSS JOIN permissions p
+WHERE r.slug = 'member'
+  AND p.slug IN ('profile.view', 'profile.update');
+
+-- Guest: read only profile
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id FROM roles r CROSS JOIN permissions p
+WHERE r.slug = 'guest'
+  AND p.slug IN ('profile.view');
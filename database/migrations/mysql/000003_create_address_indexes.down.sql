@@ -0,0 +1,2 @@
+-- Delete indexes
+DROP INDEX idx_address_user_type ON address;
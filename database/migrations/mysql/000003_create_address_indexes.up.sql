@@ -0,0 +1,5 @@
+-- -----------------------------------------------------
+-- Indexes of table 'address'
+-- -----------------------------------------------------
+-- MySQL has no partial index. One default address per type of a user is kept by the application.
+CREATE INDEX idx_address_user_type ON address (user_id, type);
@@ -0,0 +1,15 @@
+-- -----------------------------------------------------
+-- Table password_resets
+-- -----------------------------------------------------
+CREATE TABLE password_resets (
+                                 id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+                                 user_id BIGINT UNSIGNED NOT NULL,
+                                 token VARCHAR(64) NOT NULL UNIQUE,
+                                 expires_at TIMESTAMP NOT NULL,
+                                 used_at TIMESTAMP NULL,
+                                 created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                                 CONSTRAINT fk_password_reset_users
+                                     FOREIGN KEY (user_id)
+                                         REFERENCES users (id)
+                                         ON DELETE CASCADE
+);
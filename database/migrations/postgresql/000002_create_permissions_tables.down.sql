@@ -0,0 +1,3 @@
+-- Delete tables
+DROP TABLE IF EXISTS role_permissions CASCADE;
+DROP TABLE IF EXISTS permissions CASCADE;
@@ -0,0 +1,30 @@
+-- -----------------------------------------------------
+-- Table permissions
+-- -----------------------------------------------------
+CREATE TABLE permissions (
+                             id SERIAL PRIMARY KEY,
+                             name VARCHAR(100) NOT NULL,
+                             slug VARCHAR(100) NOT NULL UNIQUE,
+                             created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                             updated_at TIMESTAMP NULL
+);
+
+-- -----------------------------------------------------
+-- Table role_permissions
+-- -----------------------------------------------------
+CREATE TABLE role_permissions (
+                                  id SERIAL PRIMARY KEY,
+                                  role_id INT NOT NULL,
+                                  permission_id INT NOT NULL,
+                                  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                                  CONSTRAINT fk_role_to_permission
+                                      FOREIGN KEY (role_id)
+                                          REFERENCES roles (id)
+                                          ON DELETE CASCADE,
+                                  CONSTRAINT fk_permission_to_role
+                                      FOREIGN KEY (permission_id)
+                                          REFERENCES permissions (id)
+                                          ON DELETE CASCADE,
+                                  CONSTRAINT uq_role_permission
+                                      UNIQUE (role_id, permission_id)
+);
@@ -0,0 +1,3 @@
+-- Delete indexes
+DROP INDEX IF EXISTS uq_address_default;
+DROP INDEX IF EXISTS idx_address_user_type;
@@ -0,0 +1,7 @@
+-- -----------------------------------------------------
+-- Indexes of table 'address'
+-- -----------------------------------------------------
+CREATE INDEX idx_address_user_type ON address (user_id, type);
+
+-- Only one default address per type of a user
+CREATE UNIQUE INDEX uq_address_default ON address (user_id, type) WHERE is_default AND deleted_at IS NULL;
@@ -0,0 +1,18 @@
+-- -----------------------------------------------------
+-- Table password_resets
+-- -----------------------------------------------------
+CREATE TABLE password_resets (
+                                 id SERIAL PRIMARY KEY,
+                                 user_id INT NOT NULL,
+                                 token VARCHAR(64) NOT NULL UNIQUE,
+                                 expires_at TIMESTAMP NOT NULL,
+                                 used_at TIMESTAMP NULL,
+                                 created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                                 CONSTRAINT fk_password_reset_users
+                                     FOREIGN KEY (user_id)
+                                         REFERENCES users (id)
+                                         ON DELETE CASCADE
+);
+
+-- Add indexes
+CREATE INDEX idx_password_resets_user ON password_resets (user_id);
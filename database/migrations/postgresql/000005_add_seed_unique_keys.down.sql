@@ -0,0 +1,3 @@
+-- Delete indexes
+DROP INDEX IF EXISTS uq_user_roles_user_role;
+DROP INDEX IF EXISTS uq_roles_slug;
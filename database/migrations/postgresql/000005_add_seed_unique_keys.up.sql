@@ -0,0 +1,12 @@
+-- -----------------------------------------------------
+-- Unique keys used by seeders to upsert data
+-- -----------------------------------------------------
+-- Remove duplicated assignments before adding the unique key
+DELETE FROM user_roles a
+    USING user_roles b
+WHERE a.id > b.id
+  AND a.user_id = b.user_id
+  AND a.role_id = b.role_id;
+
+CREATE UNIQUE INDEX uq_roles_slug ON roles (slug);
+CREATE UNIQUE INDEX uq_user_roles_user_role ON user_roles (user_id, role_id);
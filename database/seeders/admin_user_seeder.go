@@ -0,0 +1,67 @@
+package seeders
+
+import (
+	"gfly/app/console/seed"
+	"gfly/app/domain/models"
+	mb "github.com/gflydev/db"
+)
+
+// ---------------------------------------------------------------
+//                      Register seeder.
+// ./artisan db:seed admin-user
+// ---------------------------------------------------------------
+
+// Auto-register seeder.
+func init() {
+	seed.Register(&AdminUserSeeder{}, "admin-user")
+}
+
+// ---------------------------------------------------------------
+//                      AdminUserSeeder struct.
+// ---------------------------------------------------------------
+
+// AdminUserSeeder struct for demo admin account seeder.
+type AdminUserSeeder struct {
+	seed.Seeder
+}
+
+// Environments The demo admin has a well-known password, never seed it in production.
+func (s *AdminUserSeeder) Environments() []string {
+	return seed.NonProduction
+}
+
+// Dependencies Roles must exist before assigning admin role.
+func (s *AdminUserSeeder) Dependencies() []string {
+	return []string{"roles"}
+}
+
+// Run Create admin account admin@gfly.dev / P@seWor9 if missing and assign admin role.
+func (s *AdminUserSeeder) Run(tx *mb.DBModel) error {
+	var userID int
+	// P@seWor9  ===>  $2a$04$9QD944312deeQjnxF.zNauGx7NQ0GtS.xJhLy.zWqWxOE8B/XCN9i
+	if err := tx.Raw(`
+		INSERT INTO users (email, password, fullname, phone, status, avatar)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (email) DO UPDATE SET email = EXCLUDED.email
+		RETURNING id`,
+		"admin@gfly.dev",
+		"$2a$04$9QD944312deeQjnxF.zNauGx7NQ0GtS.xJhLy.zWqWxOE8B/XCN9i",
+		"Admin",
+		"0989831911",
+		models.UserStatusActive,
+		"https://www.gfly.dev/assets/avatar.png",
+	).First(&userID); err != nil {
+		return err
+	}
+
+	var assigned int
+
+	return tx.Raw(`
+		WITH assigned AS (
+			INSERT INTO user_roles (role_id, user_id)
+			SELECT id, $1 FROM roles WHERE slug = $2
+			ON CONFLICT (user_id, role_id) DO NOTHING
+			RETURNING 1
+		)
+		SELECT COUNT(*) FROM assigned`, userID, models.RoleAdmin.Name()).First(&assigned)
+}
@@ -0,0 +1,70 @@
+package seeders
+
+import (
+	"fmt"
+	"gfly/app/console/seed"
+	"gfly/app/domain/models"
+	"gfly/database/factories"
+	mb "github.com/gflydev/db"
+)
+
+// ---------------------------------------------------------------
+//                      Register seeder.
+// ./artisan db:seed demo-users
+// ---------------------------------------------------------------
+
+// Auto-register seeder.
+func init() {
+	seed.Register(&DemoUserSeeder{}, "demo-users")
+}
+
+// ---------------------------------------------------------------
+//                      DemoUserSeeder struct.
+// ---------------------------------------------------------------
+
+// demoUsers number of demo members.
+const demoUsers = 10
+
+// DemoUserSeeder struct for demo members seeder.
+type DemoUserSeeder struct {
+	seed.Seeder
+}
+
+// Environments Demo users have a well-known password, never seed them in production.
+func (s *DemoUserSeeder) Environments() []string {
+	return seed.NonProduction
+}
+
+// Dependencies Roles must exist before assigning member role.
+func (s *DemoUserSeeder) Dependencies() []string {
+	return []string{"roles"}
+}
+
+// Run Create missing members user1@demo.gfly.dev ... user10@demo.gfly.dev by user factory.
+func (s *DemoUserSeeder) Run(tx *mb.DBModel) error {
+	for i := 1; i <= demoUsers; i++ {
+		email := fmt.Sprintf("user%d@demo.gfly.dev", i)
+
+		var count int
+		if err := tx.Raw("SELECT COUNT(*) FROM users WHERE email = $1", email).First(&count); err != nil {
+			return err
+		}
+		if count > 0 {
+			continue
+		}
+
+		_, err := factories.User().
+			With(factories.StateVerified).
+			Tap(func(user *models.User) {
+				user.Email = email
+			}).
+			AfterCreate(factories.AttachRoles(models.RoleMember.Name())).
+			AfterCreate(factories.WithAddresses(1)).
+			CreateIn(tx)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
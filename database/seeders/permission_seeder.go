@@ -0,0 +1,107 @@
+package seeders
+
+import (
+	"gfly/app/console/seed"
+	"gfly/app/domain/models"
+	mb "github.com/gflydev/db"
+	"strings"
+)
+
+// ---------------------------------------------------------------
+//                      Register seeder.
+// ./artisan db:seed permissions
+// ---------------------------------------------------------------
+
+// Auto-register seeder.
+func init() {
+	seed.Register(&PermissionSeeder{}, "permissions")
+}
+
+// ---------------------------------------------------------------
+//                      PermissionSeeder struct.
+// ---------------------------------------------------------------
+
+// permissions name and slug of application permissions.
+var permissions = []models.Permission{
+	{Name: "List users", Slug: models.PermissionUsersList},
+	{Name: "View user", Slug: models.PermissionUsersView},
+	{Name: "Create user", Slug: models.PermissionUsersCreate},
+	{Name: "Update user", Slug: models.PermissionUsersUpdate},
+	{Name: "Delete user", Slug: models.PermissionUsersDelete},
+	{Name: "Block user", Slug: models.PermissionUsersBlock},
+	{Name: "Set user password", Slug: models.PermissionUsersPassword},
+	{Name: "Assign roles", Slug: models.PermissionRolesAssign},
+	{Name: "View profile", Slug: models.PermissionProfileView},
+	{Name: "Update profile", Slug: models.PermissionProfileEdit},
+	{Name: "Manage system", Slug: models.PermissionSystemManage},
+}
+
+// rolePermissions permission slugs granted to role slugs. Admin is granted all permissions.
+var rolePermissions = map[string][]string{
+	models.RoleModerator.Name(): {
+		models.PermissionUsersList,
+		models.PermissionUsersView,
+		models.PermissionUsersUpdate,
+		models.PermissionUsersBlock,
+		models.PermissionProfileView,
+		models.PermissionProfileEdit,
+	},
+	models.RoleMember.Name(): {
+		models.PermissionProfileView,
+		models.PermissionProfileEdit,
+	},
+	models.RoleGuest.Name(): {
+		models.PermissionProfileView,
+	},
+}
+
+// PermissionSeeder struct for permissions seeder.
+type PermissionSeeder struct {
+	seed.Seeder
+}
+
+// Dependencies Roles must exist before granting permissions.
+func (s *PermissionSeeder) Dependencies() []string {
+	return []string{"roles"}
+}
+
+// Run Upsert permissions by slug and grant them to roles.
+func (s *PermissionSeeder) Run(tx *mb.DBModel) error {
+	all := make([]string, 0, len(permissions))
+	for _, permission := range permissions {
+		var id int
+		if err := tx.Raw(`
+			INSERT INTO permissions (name, slug) VALUES ($1, $2)
+			ON CONFLICT (slug) DO UPDATE SET name = EXCLUDED.name
+			RETURNING id`, permission.Name, permission.Slug).First(&id); err != nil {
+			return err
+		}
+		all = append(all, permission.Slug)
+	}
+
+	if err := grant(tx, models.RoleAdmin.Name(), all); err != nil {
+		return err
+	}
+	for role, slugs := range rolePermissions {
+		if err := grant(tx, role, slugs); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// grant Grant permissions to role. Existing grants are kept.
+func grant(tx *mb.DBModel, role string, slugs []string) error {
+	var granted int
+
+	return tx.Raw(`
+		WITH granted AS (
+			INSERT INTO role_permissions (role_id, permission_id)
+			SELECT r.id, p.id FROM roles r CROSS JOIN permissions p
+			WHERE r.slug = $1 AND p.slug = ANY(string_to_array($2, ','))
+			ON CONFLICT (role_id, permission_id) DO NOTHING
+			RETURNING 1
+		)
+		SELECT COUNT(*) FROM granted`, role, strings.Join(slugs, ",")).First(&granted)
+}
@@ -0,0 +1,49 @@
+package seeders
+
+import (
+	"gfly/app/console/seed"
+	"gfly/app/domain/models"
+	mb "github.com/gflydev/db"
+)
+
+// ---------------------------------------------------------------
+//                      Register seeder.
+// ./artisan db:seed roles
+// ---------------------------------------------------------------
+
+// Auto-register seeder.
+func init() {
+	seed.Register(&RoleSeeder{}, "roles")
+}
+
+// ---------------------------------------------------------------
+//                      RoleSeeder struct.
+// ---------------------------------------------------------------
+
+// roles name and slug of application roles.
+var roles = []models.Role{
+	{Name: "Admin", Slug: models.RoleAdmin.Name()},
+	{Name: "Moderator", Slug: models.RoleModerator.Name()},
+	{Name: "Member", Slug: models.RoleMember.Name()},
+	{Name: "Guest", Slug: models.RoleGuest.Name()},
+}
+
+// RoleSeeder struct for roles seeder.
+type RoleSeeder struct {
+	seed.Seeder
+}
+
+// Run Upsert roles by slug.
+func (s *RoleSeeder) Run(tx *mb.DBModel) error {
+	for _, role := range roles {
+		var id int
+		if err := tx.Raw(`
+			INSERT INTO roles (name, slug) VALUES ($1, $2)
+			ON CONFLICT (slug) DO UPDATE SET name = EXCLUDED.name
+			RETURNING id`, role.Name, role.Slug).First(&id); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
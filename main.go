@@ -1,23 +1,32 @@
 package main
 
 import (
+	"gfly/app/connections"
+	"gfly/app/domain/repository"
+	"gfly/app/errors"
+	"gfly/app/http/middleware"
 	"gfly/app/http/routes"
+	"gfly/app/lifecycle"
+	"gfly/app/metrics"
+	"gfly/app/services"
+	"gfly/app/tracing"
 	"gfly/docs"
 	"github.com/gflydev/cache"
-	cacheRedis "github.com/gflydev/cache/redis"
 	"github.com/gflydev/core"
 	"github.com/gflydev/core/log"
+	"github.com/gflydev/core/utils"
 	mb "github.com/gflydev/db"
-	dbPSQL "github.com/gflydev/db/psql"
 	notificationMail "github.com/gflydev/notification/mail"
 	"github.com/gflydev/session"
-	sessionRedis "github.com/gflydev/session/redis"
 	"github.com/gflydev/storage"
 	storageLocal "github.com/gflydev/storage/local"
 	"github.com/gflydev/view/pongo"
 	"github.com/joho/godotenv"
 )
 
+// The application must stop its listener on shutdown, see lifecycle.Manager.Drain.
+var _ lifecycle.Server = core.IFly(nil)
+
 // Main function
 // @title API
 // @version 1.0
@@ -45,9 +54,22 @@ func main() {
 		log.Fatalf("Error loading .env file %v", err)
 	}
 
+	// Refuse to start with empty secrets, anyone could forge tokens
+	if err = services.CheckSecrets(); err != nil {
+		log.Fatalf("Invalid configuration: %v", err)
+	}
+
+	// Start tracing
+	tracing.Start()
+
 	// Register view
 	core.RegisterView(pongo.New())
 
+	// Register crash reporter
+	if utils.Getenv("CRASH_REPORTER", "file") == "file" {
+		errors.RegisterReporter(errors.NewFileReporter())
+	}
+
 	// Register mail notification
 	notificationMail.AutoRegister()
 
@@ -55,22 +77,34 @@ func main() {
 	storage.Register(storageLocal.Type, storageLocal.New())
 
 	// Setup session
-	session.Register(sessionRedis.New())
+	sessionProvider := connections.Session()
+	session.Register(sessionProvider)
 	core.RegisterSession(session.New())
+	services.RegisterHealthCheck("session", services.SessionHealthCheck(sessionProvider), true)
 
-	// Register Redis cache
-	cache.Register(cacheRedis.New())
+	// Register Redis cache, tracing its commands
+	tracing.Redis(connections.CacheRedis())
+	cache.Register(metrics.Cache(connections.Cache()))
 
 	// Register DB driver & Load Model builder
-	mb.Register(dbPSQL.New())
+	mb.Register(repository.Driver(tracing.Postgres()))
 	mb.Load()
 
+	// Serve metrics at admin port
+	metrics.ListenAndServe()
+
 	// Initial application
 	app := core.New()
 
 	// Register router
 	app.RegisterRouter(routes.Router)
 
+	// On SIGTERM: fail readiness, stop the listener, drain in-flight requests then close resources
+	manager := lifecycle.New()
+	manager.Drain(app, middleware.WaitInFlight)
+	manager.CloseResources()
+
 	// Run application
-	app.Run()
+	go app.Run()
+	manager.Wait()
 }
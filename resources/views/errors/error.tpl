@@ -0,0 +1,29 @@
+<!DOCTYPE html>
+<html lang="en">
+<head>
+    <meta charset="UTF-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>{{ status }} - {{ message }}</title>
+    <style>
+        body { font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", Roboto, sans-serif; color: #333; background: #f7f7f7; margin: 0; }
+        .container { max-width: 560px; margin: 120px auto; padding: 40px; background: #fff; border-radius: 8px; text-align: center; }
+        h1 { font-size: 64px; margin: 0; color: #4f46e5; }
+        p { color: #555; }
+        pre { text-align: left; background: #f3f4f6; padding: 12px; border-radius: 4px; overflow: auto; font-size: 12px; }
+        .meta { font-size: 12px; color: #999; }
+    </style>
+</head>
+<body>
+<div class="container">
+    <h1>{{ status }}</h1>
+    <p>{{ message }}</p>
+    {% if details.debug %}
+    <pre>{{ details.debug }}</pre>
+    {% endif %}
+    <a href="/">Back to home</a>
+    {% if request_id %}
+    <p class="meta">Request ID: {{ request_id }}</p>
+    {% endif %}
+</div>
+</body>
+</html>
@@ -0,0 +1,16 @@
+<!DOCTYPE html>
+<html lang="en">
+<head>
+    <meta charset="UTF-8">
+    <title>{{ app_name }} - Reset password</title>
+</head>
+<body style="font-family: Arial, sans-serif; color: #333333;">
+<p>Hi {{ fullname|default:"there" }},</p>
+<p>We received a request to reset the password of your {{ app_name }} account.</p>
+<p>
+    <a href="{{ link }}" style="display: inline-block; padding: 10px 20px; background: #2563eb; color: #ffffff; text-decoration: none; border-radius: 4px;">Reset password</a>
+</p>
+<p>This link can be used once and expires at {{ expires_at|date:"2006-01-02 15:04 MST" }}.</p>
+<p>If you did not request a password reset, you can ignore this email. Your password will not change.</p>
+</body>
+</html>
@@ -0,0 +1,16 @@
+<!DOCTYPE html>
+<html lang="en">
+<head>
+    <meta charset="UTF-8">
+    <title>{{ app_name }} - Verify email</title>
+</head>
+<body style="font-family: Arial, sans-serif; color: #333333;">
+<p>Hi {{ fullname|default:"there" }},</p>
+<p>Thanks for signing up to {{ app_name }}. Please confirm your email address to activate your account.</p>
+<p>
+    <a href="{{ link }}" style="display: inline-block; padding: 10px 20px; background: #2563eb; color: #ffffff; text-decoration: none; border-radius: 4px;">Verify email</a>
+</p>
+<p>This link expires at {{ expires_at|date:"2006-01-02 15:04 MST" }}.</p>
+<p>If you did not create an account, you can ignore this email.</p>
+</body>
+</html>